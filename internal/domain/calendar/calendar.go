@@ -0,0 +1,102 @@
+// Package calendar is the B3 trading calendar: weekdays without the exchange holidays. Local
+// holidays of São Paulo are trading days and are not listed.
+package calendar
+
+import "time"
+
+// blackConsciousnessYear is the first year Black Consciousness Day closed the exchange as a
+// national holiday.
+const blackConsciousnessYear = 2024
+
+// fixedHolidays are the holidays on the same date every year, including the last two days of the
+// year, when B3 holds no session.
+var fixedHolidays = []struct {
+	month time.Month
+	day   int
+}{
+	{time.January, 1},
+	{time.April, 21},
+	{time.May, 1},
+	{time.September, 7},
+	{time.October, 12},
+	{time.November, 2},
+	{time.November, 15},
+	{time.December, 24},
+	{time.December, 25},
+	{time.December, 31},
+}
+
+// IsTradingDay reports whether B3 holds a session on the date of d.
+func IsTradingDay(d time.Time) bool {
+	d = day(d)
+	if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return false
+	}
+	return !isHoliday(d)
+}
+
+// TradingDays returns the trading days between from and to, inclusive, at midnight UTC.
+func TradingDays(from, to time.Time) []time.Time {
+	var days []time.Time
+	for d := day(from); !d.After(day(to)); d = d.AddDate(0, 0, 1) {
+		if IsTradingDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// AddTradingDays moves n trading days forward from the date of d, or backward when n is negative.
+func AddTradingDays(d time.Time, n int) time.Time {
+	d = day(d)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if IsTradingDay(d) {
+			n--
+		}
+	}
+	return d
+}
+
+func isHoliday(d time.Time) bool {
+	for _, h := range fixedHolidays {
+		if d.Month() == h.month && d.Day() == h.day {
+			return true
+		}
+	}
+	if d.Year() >= blackConsciousnessYear && d.Month() == time.November && d.Day() == 20 {
+		return true
+	}
+	easter := easterSunday(d.Year())
+	for _, offset := range []int{-48, -47, -2, 60} { // Carnival Monday and Tuesday, Good Friday, Corpus Christi
+		if d.Equal(easter.AddDate(0, 0, offset)) {
+			return true
+		}
+	}
+	return false
+}
+
+// easterSunday uses the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	dayOfMonth := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), dayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIsTradingDayGivenExchangeHolidaysWhenCalledThenReturnsFalse(t *testing.T) {
+	// Arrange
+	holidays := []time.Time{
+		date(2025, time.January, 1),
+		date(2025, time.March, 3),  // Carnival Monday
+		date(2025, time.March, 4),  // Carnival Tuesday
+		date(2025, time.April, 18), // Good Friday
+		date(2025, time.April, 21),
+		date(2025, time.May, 1),
+		date(2025, time.June, 19), // Corpus Christi
+		date(2025, time.November, 20),
+		date(2025, time.December, 24),
+		date(2025, time.December, 31),
+		date(2024, time.February, 13), // Carnival Tuesday
+	}
+
+	for _, d := range holidays {
+		// Act
+		trading := IsTradingDay(d)
+
+		// Assert
+		assert.False(t, trading, d.Format("2006-01-02"))
+	}
+}
+
+func TestIsTradingDayGivenRegularWeekdayWhenCalledThenReturnsTrue(t *testing.T) {
+	// Arrange
+	days := []time.Time{date(2025, time.July, 29), date(2023, time.November, 20), date(2024, time.January, 25)}
+
+	for _, d := range days {
+		// Act
+		trading := IsTradingDay(d)
+
+		// Assert
+		assert.True(t, trading, d.Format("2006-01-02"))
+	}
+}
+
+func TestTradingDaysGivenRangeWithWeekendAndHolidayWhenCalledThenSkipsThem(t *testing.T) {
+	// Act
+	days := TradingDays(date(2025, time.April, 17), date(2025, time.April, 22))
+
+	// Assert
+	assert.Equal(t, []time.Time{date(2025, time.April, 17), date(2025, time.April, 22)}, days)
+}
+
+func TestAddTradingDaysGivenNegativeCountWhenCalledThenMovesBackOverHolidays(t *testing.T) {
+	// Act
+	d := AddTradingDays(date(2025, time.April, 22), -2)
+
+	// Assert
+	assert.Equal(t, date(2025, time.April, 16), d)
+}
@@ -0,0 +1,125 @@
+// Package classifier derives the asset class of a B3 instrument and the parts encoded in its
+// code: the underlying root, the option series and strike code, and the month and year of a future.
+package classifier
+
+import (
+	"b3-ingest/internal/domain/futures"
+	"regexp"
+	"time"
+)
+
+// AssetClass is the kind of instrument a code stands for.
+type AssetClass string
+
+const (
+	AssetEquity     AssetClass = "equity"
+	AssetFractional AssetClass = "fractional"
+	AssetUnit       AssetClass = "unit"
+	AssetETF        AssetClass = "etf"
+	AssetBDR        AssetClass = "bdr"
+	AssetOption     AssetClass = "option"
+	AssetFuture     AssetClass = "future"
+	AssetOther      AssetClass = "other"
+)
+
+// Option types, given by the series letter: A to L are calls and M to X puts.
+const (
+	OptionCall = "call"
+	OptionPut  = "put"
+)
+
+// Contracts of known futures roots are checked first, so long-dated codes such as DI1F33 are not
+// taken for BDRs. Share codes come next, so units such as KLBN11 are not taken for a contract of
+// an unknown root expiring in 2011.
+var (
+	shareCodeRe  = regexp.MustCompile(`^([A-Z0-9]{4})([3-8]|11|3[2-9])(F?)$`)
+	optionCodeRe = regexp.MustCompile(`^([A-Z0-9]{4})([A-X])(\d+[A-Z0-9]*)$`)
+)
+
+// etfRoots lists the ETFs listed on B3. Code 11 is shared by units, ETFs and real estate funds,
+// so only these roots are told apart; other 11 codes are classified as units.
+var etfRoots = map[string]bool{
+	"BOVA": true, "BOVB": true, "BOVV": true, "BOVX": true, "XBOV": true, "BRAX": true,
+	"SMAL": true, "SMAC": true, "IVVB": true, "SPXI": true, "NASD": true, "ACWI": true,
+	"EURP": true, "WRLD": true, "DIVO": true, "PIBB": true, "ECOO": true, "MATB": true,
+	"FIND": true, "GOVE": true, "BBSD": true, "HASH": true, "QBTC": true, "ETHE": true,
+	"DEFI": true, "GOLD": true, "IMAB": true, "IB5M": true, "B5P2": true, "FIXA": true,
+	"XFIX": true,
+}
+
+// Classification is what can be read from an instrument code. Fields that do not apply to the
+// asset class are left empty.
+type Classification struct {
+	Ticker     string
+	AssetClass AssetClass
+	// Root is the four-letter issuer code of shares and options, or the contract root of futures.
+	Root string
+	// OptionType, OptionSeries and StrikeCode are set for options; the series letter also gives
+	// the expiry month in Month.
+	OptionType   string
+	OptionSeries string
+	StrikeCode   string
+	// Month is the expiry month of futures and options; Year is only known for futures.
+	Month time.Month
+	Year  int
+}
+
+// ValidAssetClass reports whether class is one of the asset classes returned by Classify.
+func ValidAssetClass(class string) bool {
+	switch AssetClass(class) {
+	case AssetEquity, AssetFractional, AssetUnit, AssetETF, AssetBDR, AssetOption, AssetFuture, AssetOther:
+		return true
+	}
+	return false
+}
+
+// Classify derives the classification of a B3 instrument code.
+func Classify(ticker string) Classification {
+	c := Classification{Ticker: ticker, AssetClass: AssetOther}
+	contract, err := futures.ParseContract(ticker)
+	isContract := err == nil
+	if isContract && futures.KnownRoot(contract.Root) {
+		return future(c, contract)
+	}
+	if m := shareCodeRe.FindStringSubmatch(ticker); m != nil {
+		c.Root = m[1]
+		switch {
+		case m[3] == "F":
+			c.AssetClass = AssetFractional
+		case m[2] == "11" && etfRoots[m[1]]:
+			c.AssetClass = AssetETF
+		case m[2] == "11":
+			c.AssetClass = AssetUnit
+		case len(m[2]) == 2:
+			c.AssetClass = AssetBDR
+		default:
+			c.AssetClass = AssetEquity
+		}
+		return c
+	}
+	if isContract {
+		return future(c, contract)
+	}
+	if m := optionCodeRe.FindStringSubmatch(ticker); m != nil {
+		series := m[2][0]
+		c.AssetClass = AssetOption
+		c.Root = m[1]
+		c.OptionSeries = m[2]
+		c.StrikeCode = m[3]
+		c.OptionType = OptionCall
+		c.Month = time.Month(series-'A') + 1
+		if series >= 'M' {
+			c.OptionType = OptionPut
+			c.Month = time.Month(series-'M') + 1
+		}
+	}
+	return c
+}
+
+func future(c Classification, contract futures.Contract) Classification {
+	c.AssetClass = AssetFuture
+	c.Root = contract.Root
+	c.Month = contract.Month
+	c.Year = contract.Year
+	return c
+}
@@ -0,0 +1,90 @@
+package classifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyGivenB3CodesWhenClassifiedThenReturnsAssetClassAndRoot(t *testing.T) {
+	// Arrange
+	cases := map[string]struct {
+		class AssetClass
+		root  string
+	}{
+		"PETR4":  {AssetEquity, "PETR"},
+		"VALE3":  {AssetEquity, "VALE"},
+		"PETR4F": {AssetFractional, "PETR"},
+		"KLBN11": {AssetUnit, "KLBN"},
+		"BOVA11": {AssetETF, "BOVA"},
+		"AAPL34": {AssetBDR, "AAPL"},
+		"WDOQ25": {AssetFuture, "WDO"},
+		"DI1F26": {AssetFuture, "DI1"},
+		"DI1F33": {AssetFuture, "DI1"},
+		"DI1F35": {AssetFuture, "DI1"},
+		"XYZ":    {AssetOther, ""},
+	}
+
+	for code, expected := range cases {
+		// Act
+		got := Classify(code)
+
+		// Assert
+		assert.Equal(t, code, got.Ticker)
+		assert.Equal(t, expected.class, got.AssetClass, code)
+		assert.Equal(t, expected.root, got.Root, code)
+	}
+}
+
+func TestClassifyGivenCallOptionWhenClassifiedThenReturnsSeriesAndStrikeCode(t *testing.T) {
+	// Act
+	got := Classify("PETRH250")
+
+	// Assert
+	assert.Equal(t, AssetOption, got.AssetClass)
+	assert.Equal(t, "PETR", got.Root)
+	assert.Equal(t, OptionCall, got.OptionType)
+	assert.Equal(t, "H", got.OptionSeries)
+	assert.Equal(t, "250", got.StrikeCode)
+	assert.Equal(t, time.August, got.Month)
+	assert.Zero(t, got.Year)
+}
+
+func TestClassifyGivenPutOptionWhenClassifiedThenReturnsExpiryMonth(t *testing.T) {
+	// Act
+	got := Classify("VALEX620W2")
+
+	// Assert
+	assert.Equal(t, AssetOption, got.AssetClass)
+	assert.Equal(t, OptionPut, got.OptionType)
+	assert.Equal(t, "620W2", got.StrikeCode)
+	assert.Equal(t, time.December, got.Month)
+}
+
+func TestClassifyGivenFutureWhenClassifiedThenReturnsMonthAndYear(t *testing.T) {
+	// Act
+	got := Classify("WINV25")
+
+	// Assert
+	assert.Equal(t, time.October, got.Month)
+	assert.Equal(t, 2025, got.Year)
+	assert.Empty(t, got.OptionType)
+}
+
+func TestClassifyGivenLongDatedFutureWhenClassifiedThenIsNotBDR(t *testing.T) {
+	// Act
+	got := Classify("DI1F33")
+
+	// Assert
+	assert.Equal(t, AssetFuture, got.AssetClass)
+	assert.Equal(t, time.January, got.Month)
+	assert.Equal(t, 2033, got.Year)
+}
+
+func TestValidAssetClassGivenNamesWhenCheckedThenAcceptsOnlyKnownClasses(t *testing.T) {
+	// Assert
+	assert.True(t, ValidAssetClass("option"))
+	assert.True(t, ValidAssetClass("etf"))
+	assert.False(t, ValidAssetClass("crypto"))
+}
@@ -0,0 +1,209 @@
+// Package comparison aligns the daily closes of several tickers on a common calendar and measures
+// how their returns move together.
+package comparison
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// GapPolicy decides what happens on sessions where a ticker did not trade.
+type GapPolicy string
+
+const (
+	// GapForwardFill carries the last close forward, so a missing session has a zero return.
+	GapForwardFill GapPolicy = "ffill"
+	// GapDrop keeps only the sessions where every ticker traded.
+	GapDrop GapPolicy = "drop"
+	// GapNull leaves missing sessions empty; a return needs closes on both sessions.
+	GapNull GapPolicy = "null"
+)
+
+// ParseGapPolicy validates a gap policy; an empty name selects GapForwardFill.
+func ParseGapPolicy(name string) (GapPolicy, error) {
+	switch GapPolicy(name) {
+	case "":
+		return GapForwardFill, nil
+	case GapForwardFill, GapDrop, GapNull:
+		return GapPolicy(name), nil
+	}
+	return "", fmt.Errorf("invalid gap policy %q, use ffill, drop or null", name)
+}
+
+// Observation is the close of a ticker on one session.
+type Observation struct {
+	Date  time.Time
+	Close float64
+}
+
+// Comparison holds series aligned on Dates, one entry per session. Empty entries are nil.
+// Correlation follows the order of Tickers; Spread is the close of the first ticker minus the
+// close of the second. Statistics use the sessions where both series have a value and are nil
+// when fewer than two such sessions exist or a series is constant.
+type Comparison struct {
+	Tickers     []string
+	Dates       []time.Time
+	Closes      map[string][]*float64
+	Returns     map[string][]*float64
+	Correlation [][]*float64
+	Beta        map[string]*float64
+	Spread      []*float64
+}
+
+// Compare aligns the closes in observations, which must be ordered by date, on the union of their
+// sessions and applies policy to the gaps. Beta is measured against the returns of benchmark.
+func Compare(tickers []string, benchmark string, policy GapPolicy, observations map[string][]Observation) Comparison {
+	c := Comparison{
+		Tickers: tickers,
+		Closes:  make(map[string][]*float64, len(tickers)),
+		Returns: make(map[string][]*float64, len(tickers)),
+		Beta:    make(map[string]*float64, len(tickers)),
+	}
+	c.Dates = unionDates(observations)
+	index := make(map[time.Time]int, len(c.Dates))
+	for i, d := range c.Dates {
+		index[d] = i
+	}
+	for _, t := range tickers {
+		closes := make([]*float64, len(c.Dates))
+		for _, o := range observations[t] {
+			v := o.Close
+			closes[index[o.Date]] = &v
+		}
+		if policy == GapForwardFill {
+			for i := 1; i < len(closes); i++ {
+				if closes[i] == nil {
+					closes[i] = closes[i-1]
+				}
+			}
+		}
+		c.Closes[t] = closes
+	}
+	if policy == GapDrop {
+		c.dropGaps()
+	}
+
+	for _, t := range tickers {
+		c.Returns[t] = returns(c.Closes[t])
+	}
+	c.Correlation = make([][]*float64, len(tickers))
+	for i, a := range tickers {
+		c.Correlation[i] = make([]*float64, len(tickers))
+		for j, b := range tickers {
+			c.Correlation[i][j] = pearson(c.Returns[a], c.Returns[b])
+		}
+	}
+	for _, t := range tickers {
+		c.Beta[t] = beta(c.Returns[t], c.Returns[benchmark])
+	}
+	if len(tickers) >= 2 {
+		first, second := c.Closes[tickers[0]], c.Closes[tickers[1]]
+		c.Spread = make([]*float64, len(c.Dates))
+		for i := range c.Dates {
+			if first[i] != nil && second[i] != nil {
+				v := *first[i] - *second[i]
+				c.Spread[i] = &v
+			}
+		}
+	}
+	return c
+}
+
+func unionDates(observations map[string][]Observation) []time.Time {
+	seen := map[time.Time]bool{}
+	var dates []time.Time
+	for _, obs := range observations {
+		for _, o := range obs {
+			if !seen[o.Date] {
+				seen[o.Date] = true
+				dates = append(dates, o.Date)
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// dropGaps removes the sessions where any ticker has no close.
+func (c *Comparison) dropGaps() {
+	var keep []int
+	for i := range c.Dates {
+		complete := true
+		for _, t := range c.Tickers {
+			complete = complete && c.Closes[t][i] != nil
+		}
+		if complete {
+			keep = append(keep, i)
+		}
+	}
+	dates := make([]time.Time, 0, len(keep))
+	for _, i := range keep {
+		dates = append(dates, c.Dates[i])
+	}
+	c.Dates = dates
+	for _, t := range c.Tickers {
+		closes := make([]*float64, 0, len(keep))
+		for _, i := range keep {
+			closes = append(closes, c.Closes[t][i])
+		}
+		c.Closes[t] = closes
+	}
+}
+
+// returns gives the simple return of each session over the previous one.
+func returns(closes []*float64) []*float64 {
+	out := make([]*float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		if closes[i] != nil && closes[i-1] != nil && *closes[i-1] != 0 {
+			v := *closes[i] / *closes[i-1] - 1
+			out[i] = &v
+		}
+	}
+	return out
+}
+
+// moments returns the covariance of x and y and the variances of x and y over the sessions where
+// both have a value, together with the number of such sessions.
+func moments(x, y []*float64) (cov, varX, varY float64, n int) {
+	var sumX, sumY float64
+	for i := range x {
+		if x[i] != nil && y[i] != nil {
+			sumX += *x[i]
+			sumY += *y[i]
+			n++
+		}
+	}
+	if n < 2 {
+		return 0, 0, 0, n
+	}
+	meanX, meanY := sumX/float64(n), sumY/float64(n)
+	for i := range x {
+		if x[i] != nil && y[i] != nil {
+			dx, dy := *x[i]-meanX, *y[i]-meanY
+			cov += dx * dy
+			varX += dx * dx
+			varY += dy * dy
+		}
+	}
+	return cov, varX, varY, n
+}
+
+func pearson(x, y []*float64) *float64 {
+	cov, varX, varY, n := moments(x, y)
+	if n < 2 || varX == 0 || varY == 0 {
+		return nil
+	}
+	v := cov / math.Sqrt(varX*varY)
+	return &v
+}
+
+func beta(x, benchmark []*float64) *float64 {
+	cov, _, varB, n := moments(x, benchmark)
+	if n < 2 || varB == 0 {
+		return nil
+	}
+	v := cov / varB
+	return &v
+}
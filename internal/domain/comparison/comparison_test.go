@@ -0,0 +1,123 @@
+package comparison
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func observations(days []int, closes ...float64) []Observation {
+	out := make([]Observation, len(days))
+	for i, d := range days {
+		out[i] = Observation{Date: day(d), Close: closes[i]}
+	}
+	return out
+}
+
+func TestCompareGivenAlignedClosesWhenComparedThenMatchesKnownStatistics(t *testing.T) {
+	// Arrange
+	// Returns are 2%, -2%, 2% for A and 4%, -8%, 10% for B.
+	obs := map[string][]Observation{
+		"A": observations([]int{21, 22, 23, 24}, 100, 102, 99.96, 101.9592),
+		"B": observations([]int{21, 22, 23, 24}, 50, 52, 47.84, 52.624),
+	}
+
+	// Act
+	c := Compare([]string{"A", "B"}, "A", GapForwardFill, obs)
+
+	// Assert
+	assert.Len(t, c.Dates, 4)
+	assert.Nil(t, c.Returns["A"][0])
+	assert.InDelta(t, 0.02, *c.Returns["A"][1], 1e-9)
+	assert.InDelta(t, -0.08, *c.Returns["B"][2], 1e-9)
+	assert.InDelta(t, 1, *c.Correlation[0][0], 1e-9)
+	assert.InDelta(t, 0.944911, *c.Correlation[0][1], 1e-6)
+	assert.InDelta(t, 0.944911, *c.Correlation[1][0], 1e-6)
+	assert.InDelta(t, 1, *c.Beta["A"], 1e-9)
+	assert.InDelta(t, 3.75, *c.Beta["B"], 1e-9)
+	assert.InDelta(t, 50, *c.Spread[0], 1e-9)
+	assert.InDelta(t, 49.3352, *c.Spread[3], 1e-9)
+}
+
+func TestCompareGivenGapAndForwardFillWhenComparedThenCarriesCloseAndReturnsZero(t *testing.T) {
+	// Arrange
+	obs := map[string][]Observation{
+		"A": observations([]int{21, 22, 23}, 10, 11, 12),
+		"B": observations([]int{21, 23}, 20, 22),
+	}
+
+	// Act
+	c := Compare([]string{"A", "B"}, "A", GapForwardFill, obs)
+
+	// Assert
+	assert.Len(t, c.Dates, 3)
+	assert.Equal(t, 20.0, *c.Closes["B"][1])
+	assert.Equal(t, 0.0, *c.Returns["B"][1])
+	assert.InDelta(t, 0.1, *c.Returns["B"][2], 1e-9)
+}
+
+func TestCompareGivenGapAndNullPolicyWhenComparedThenLeavesReturnsEmpty(t *testing.T) {
+	// Arrange
+	obs := map[string][]Observation{
+		"A": observations([]int{21, 22, 23}, 10, 11, 12),
+		"B": observations([]int{21, 23}, 20, 22),
+	}
+
+	// Act
+	c := Compare([]string{"A", "B"}, "A", GapNull, obs)
+
+	// Assert
+	assert.Len(t, c.Dates, 3)
+	assert.Nil(t, c.Closes["B"][1])
+	assert.Nil(t, c.Returns["B"][1])
+	assert.Nil(t, c.Returns["B"][2])
+	assert.Nil(t, c.Spread[1])
+	assert.Nil(t, c.Correlation[0][1])
+}
+
+func TestCompareGivenGapAndDropPolicyWhenComparedThenKeepsCommonSessions(t *testing.T) {
+	// Arrange
+	obs := map[string][]Observation{
+		"A": observations([]int{21, 22, 23}, 10, 11, 12),
+		"B": observations([]int{21, 23, 24}, 20, 22, 23),
+	}
+
+	// Act
+	c := Compare([]string{"A", "B"}, "A", GapDrop, obs)
+
+	// Assert
+	assert.Equal(t, []time.Time{day(21), day(23)}, c.Dates)
+	assert.InDelta(t, 0.2, *c.Returns["A"][1], 1e-9)
+	assert.InDelta(t, 0.1, *c.Returns["B"][1], 1e-9)
+}
+
+func TestCompareGivenInverseReturnsWhenComparedThenCorrelationIsMinusOne(t *testing.T) {
+	// Arrange
+	obs := map[string][]Observation{
+		"A": observations([]int{21, 22, 23, 24}, 100, 110, 99, 108.9),
+		"B": observations([]int{21, 22, 23, 24}, 100, 90, 99, 89.1),
+	}
+
+	// Act
+	c := Compare([]string{"A", "B"}, "B", GapForwardFill, obs)
+
+	// Assert
+	assert.InDelta(t, -1, *c.Correlation[0][1], 1e-9)
+	assert.InDelta(t, -1, *c.Beta["A"], 1e-9)
+}
+
+func TestParseGapPolicyGivenNamesWhenParsedThenDefaultsToForwardFill(t *testing.T) {
+	// Act
+	empty, err := ParseGapPolicy("")
+	_, invalidErr := ParseGapPolicy("interpolate")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, GapForwardFill, empty)
+	assert.Error(t, invalidErr)
+}
@@ -0,0 +1,158 @@
+package futures
+
+import (
+	"b3-ingest/internal/domain/calendar"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RollMethod selects when a continuous series moves on to the next contract.
+type RollMethod string
+
+const (
+	// RollOnVolume rolls the session after a later contract trades more than the current one.
+	RollOnVolume RollMethod = "volume"
+	// RollBeforeExpiry rolls a fixed number of business days before the current contract expires.
+	RollBeforeExpiry RollMethod = "expiry"
+)
+
+// RollRule is a roll method and, for RollBeforeExpiry, how many business days before expiry to roll.
+type RollRule struct {
+	Method           RollMethod
+	DaysBeforeExpiry int
+}
+
+// ParseRollRule validates a roll rule read from a request or the environment.
+func ParseRollRule(method string, daysBeforeExpiry int) (RollRule, error) {
+	switch m := RollMethod(method); m {
+	case RollOnVolume, RollBeforeExpiry:
+		if daysBeforeExpiry < 0 {
+			return RollRule{}, fmt.Errorf("days before expiry must not be negative, got %d", daysBeforeExpiry)
+		}
+		return RollRule{Method: m, DaysBeforeExpiry: daysBeforeExpiry}, nil
+	default:
+		return RollRule{}, fmt.Errorf("invalid roll method %q, use volume or expiry", method)
+	}
+}
+
+// Bar is the daily bar of a single contract.
+type Bar struct {
+	Contract Contract
+	Date     time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   int64
+	Trades   int64
+}
+
+// ContinuousBar is a session of a continuous series: the bar of the contract held that day.
+// Rolled marks the first session of a new contract. Prices are not adjusted across rolls.
+type ContinuousBar struct {
+	Bar
+	Rolled bool
+}
+
+// Continuous stitches the bars of the contracts of one root into a front-month series following
+// rule. The series only moves forward: once rolled, an earlier contract is never picked again.
+// Sessions without an eligible contract are left out.
+func Continuous(bars []Bar, rule RollRule) ([]ContinuousBar, error) {
+	expiries := map[string]time.Time{}
+	byDate := map[time.Time][]Bar{}
+	for _, b := range bars {
+		if _, ok := expiries[b.Contract.Code]; !ok {
+			exp, err := b.Contract.Expiry()
+			if err != nil {
+				return nil, err
+			}
+			expiries[b.Contract.Code] = exp
+		}
+		byDate[b.Date] = append(byDate[b.Date], b)
+	}
+	dates := make([]time.Time, 0, len(byDate))
+	for d, day := range byDate {
+		dates = append(dates, d)
+		sort.Slice(day, func(i, j int) bool { return expiries[day[i].Contract.Code].Before(expiries[day[j].Contract.Code]) })
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	s := &stitcher{rule: rule, expiries: expiries}
+	series := make([]ContinuousBar, 0, len(dates))
+	for _, d := range dates {
+		bar, ok := s.pick(d, byDate[d])
+		if !ok {
+			continue
+		}
+		rolled := s.current != "" && s.current != bar.Contract.Code
+		s.current, s.currentExpiry = bar.Contract.Code, expiries[bar.Contract.Code]
+		series = append(series, ContinuousBar{Bar: bar, Rolled: rolled})
+		if rule.Method == RollOnVolume {
+			s.pending = s.crossover(bar, byDate[d])
+		}
+	}
+	return series, nil
+}
+
+// stitcher tracks the contract held by a continuous series while it walks through the sessions.
+type stitcher struct {
+	rule          RollRule
+	expiries      map[string]time.Time
+	current       string
+	currentExpiry time.Time
+	// pending is the contract that outtraded the current one, held from the next session on.
+	pending string
+}
+
+// pick returns the bar of the contract to hold on d. day is sorted by expiry.
+func (s *stitcher) pick(d time.Time, day []Bar) (Bar, bool) {
+	if s.rule.Method == RollBeforeExpiry {
+		for _, b := range day {
+			exp := s.expiries[b.Contract.Code]
+			if !exp.Before(s.currentExpiry) && d.Before(calendar.AddTradingDays(exp, -s.rule.DaysBeforeExpiry)) {
+				return b, true
+			}
+		}
+		// Past the roll date with the next contract not traded yet, keep the front contract until it expires.
+		for _, b := range day {
+			exp := s.expiries[b.Contract.Code]
+			if !exp.Before(s.currentExpiry) && !d.After(exp) {
+				return b, true
+			}
+		}
+		return Bar{}, false
+	}
+
+	for _, code := range []string{s.pending, s.current} {
+		for _, b := range day {
+			if code != "" && b.Contract.Code == code {
+				s.pending = ""
+				return b, true
+			}
+		}
+	}
+	// The held contract did not trade, or nothing is held yet: take the most traded eligible one.
+	var best Bar
+	found := false
+	for _, b := range day {
+		if s.expiries[b.Contract.Code].Before(s.currentExpiry) {
+			continue
+		}
+		if !found || b.Volume > best.Volume {
+			best, found = b, true
+		}
+	}
+	return best, found
+}
+
+// crossover returns the later contract that traded more than held on the same session, if any.
+func (s *stitcher) crossover(held Bar, day []Bar) string {
+	next, volume := "", held.Volume
+	for _, b := range day {
+		if s.expiries[b.Contract.Code].After(s.currentExpiry) && b.Volume > volume {
+			next, volume = b.Contract.Code, b.Volume
+		}
+	}
+	return next
+}
@@ -0,0 +1,108 @@
+// Package futures knows how B3 codes its futures contracts and when they expire, and stitches
+// the daily bars of consecutive contracts into continuous series.
+package futures
+
+import (
+	"b3-ingest/internal/domain/calendar"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrUnknownRoot is returned for contract roots whose expiry rule is not known.
+	ErrUnknownRoot = errors.New("unknown futures root")
+
+	contractRe = regexp.MustCompile(`^([A-Z][A-Z0-9]{2})([FGHJKMNQUVXZ])(\d{2})$`)
+)
+
+// monthCodes maps the B3 month letters to calendar months.
+var monthCodes = map[byte]time.Month{
+	'F': time.January, 'G': time.February, 'H': time.March, 'J': time.April,
+	'K': time.May, 'M': time.June, 'N': time.July, 'Q': time.August,
+	'U': time.September, 'V': time.October, 'X': time.November, 'Z': time.December,
+}
+
+// expiryRules gives the last trading day of a contract of each known root for its month. Days
+// without a session in the B3 calendar are skipped.
+var expiryRules = map[string]func(year int, month time.Month) time.Time{
+	"WDO": firstBusinessDay, // mini dólar
+	"DOL": firstBusinessDay, // dólar cheio
+	"DI1": firstBusinessDay, // DI de um dia
+	"WIN": wednesdayNearest15th,
+	"IND": wednesdayNearest15th,
+	"BGI": lastBusinessDay, // boi gordo
+	"CCM": businessDayFrom15th,
+}
+
+// Contract is a parsed futures code such as WDOQ25: root WDO, August 2025.
+type Contract struct {
+	Code  string
+	Root  string
+	Month time.Month
+	Year  int
+}
+
+// ParseContract splits a B3 futures code into root, month letter and two-digit year.
+func ParseContract(code string) (Contract, error) {
+	m := contractRe.FindStringSubmatch(code)
+	if m == nil {
+		return Contract{}, fmt.Errorf("%q is not a futures contract code", code)
+	}
+	yy, _ := strconv.Atoi(m[3])
+	return Contract{Code: code, Root: m[1], Month: monthCodes[m[2][0]], Year: 2000 + yy}, nil
+}
+
+// KnownRoot reports whether the expiry rule of root is known.
+func KnownRoot(root string) bool {
+	_, ok := expiryRules[root]
+	return ok
+}
+
+// Expiry returns the last trading day of c, or ErrUnknownRoot.
+func (c Contract) Expiry() (time.Time, error) {
+	rule, ok := expiryRules[c.Root]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w %q", ErrUnknownRoot, c.Root)
+	}
+	return rule(c.Year, c.Month), nil
+}
+
+func firstBusinessDay(year int, month time.Month) time.Time {
+	d := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	for !calendar.IsTradingDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+func lastBusinessDay(year int, month time.Month) time.Time {
+	d := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	for !calendar.IsTradingDay(d) {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+func businessDayFrom15th(year int, month time.Month) time.Time {
+	d := time.Date(year, month, 15, 0, 0, 0, 0, time.UTC)
+	for !calendar.IsTradingDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// wednesdayNearest15th is the expiry of the Ibovespa futures. The only Wednesday between the
+// 12th and the 18th is the one nearest to the 15th; a holiday moves it to the next session.
+func wednesdayNearest15th(year int, month time.Month) time.Time {
+	d := time.Date(year, month, 12, 0, 0, 0, 0, time.UTC)
+	for d.Weekday() != time.Wednesday {
+		d = d.AddDate(0, 0, 1)
+	}
+	for !calendar.IsTradingDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
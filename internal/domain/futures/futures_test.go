@@ -0,0 +1,141 @@
+package futures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseContractGivenFuturesCodeWhenParsedThenSplitsRootMonthAndYear(t *testing.T) {
+	// Act
+	c, err := ParseContract("WDOQ25")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, Contract{Code: "WDOQ25", Root: "WDO", Month: time.August, Year: 2025}, c)
+}
+
+func TestParseContractGivenNonFuturesCodeWhenParsedThenReturnsError(t *testing.T) {
+	for _, code := range []string{"PETR4", "WDOQ25C005500", "WDOA25", "wdoq25"} {
+		// Act
+		_, err := ParseContract(code)
+
+		// Assert
+		assert.Error(t, err, code)
+	}
+}
+
+func TestExpiryGivenKnownRootsWhenComputedThenFollowsB3Rules(t *testing.T) {
+	cases := map[string]time.Time{
+		"WDOQ25": date(2025, 8, 1),   // first business day
+		"DOLN25": date(2025, 7, 1),   // first business day
+		"DI1F26": date(2026, 1, 2),   // 2026-01-01 is a holiday
+		"WDOF26": date(2026, 1, 2),   // 2026-01-01 is a holiday
+		"WDOF27": date(2027, 1, 4),   // 2027-01-01 is a holiday on a Friday
+		"WDOM25": date(2025, 6, 2),   // 2025-06-01 is a Sunday
+		"WINV25": date(2025, 10, 15), // the 15th is a Wednesday
+		"WINZ25": date(2025, 12, 17), // Wednesday nearest the 15th
+		"BGIQ25": date(2025, 8, 29),  // last business day, the 31st is a Sunday
+		"CCMN25": date(2025, 7, 15),  // the 15th
+	}
+	for code, want := range cases {
+		c, err := ParseContract(code)
+		assert.NoError(t, err)
+
+		// Act
+		got, err := c.Expiry()
+
+		// Assert
+		assert.NoError(t, err, code)
+		assert.Equal(t, want, got, code)
+	}
+}
+
+func TestExpiryGivenUnknownRootWhenComputedThenReturnsUnknownRoot(t *testing.T) {
+	// Arrange
+	c, _ := ParseContract("XYZQ25")
+
+	// Act
+	_, err := c.Expiry()
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownRoot)
+}
+
+func bar(code string, d time.Time, close float64, volume int64) Bar {
+	c, _ := ParseContract(code)
+	return Bar{Contract: c, Date: d, Close: close, Volume: volume}
+}
+
+func TestContinuousGivenVolumeRuleWhenNextContractOuttradesThenRollsNextSession(t *testing.T) {
+	// Arrange
+	bars := []Bar{
+		bar("WDOQ25", date(2025, 7, 28), 5500, 1000), bar("WDOU25", date(2025, 7, 28), 5530, 100),
+		bar("WDOQ25", date(2025, 7, 29), 5510, 400), bar("WDOU25", date(2025, 7, 29), 5540, 900),
+		bar("WDOQ25", date(2025, 7, 30), 5520, 300), bar("WDOU25", date(2025, 7, 30), 5550, 1200),
+		bar("WDOQ25", date(2025, 7, 31), 5525, 2000), bar("WDOU25", date(2025, 7, 31), 5555, 1500),
+	}
+	rule, _ := ParseRollRule("volume", 0)
+
+	// Act
+	series, err := Continuous(bars, rule)
+
+	// Assert
+	assert.NoError(t, err)
+	codes := make([]string, 0, len(series))
+	for _, b := range series {
+		codes = append(codes, b.Contract.Code)
+	}
+	assert.Equal(t, []string{"WDOQ25", "WDOQ25", "WDOU25", "WDOU25"}, codes)
+	assert.True(t, series[2].Rolled)
+	assert.False(t, series[3].Rolled)
+	assert.Equal(t, 5550.0, series[2].Close)
+}
+
+func TestContinuousGivenExpiryRuleWhenRollDateIsReachedThenRollsToNextContract(t *testing.T) {
+	// Arrange
+	// WDOQ25 expires on Friday 2025-08-01, so two business days before is Wednesday 2025-07-30.
+	bars := []Bar{
+		bar("WDOQ25", date(2025, 7, 29), 5510, 900), bar("WDOU25", date(2025, 7, 29), 5540, 100),
+		bar("WDOQ25", date(2025, 7, 30), 5520, 900), bar("WDOU25", date(2025, 7, 30), 5550, 100),
+		bar("WDOQ25", date(2025, 7, 31), 5525, 900), bar("WDOU25", date(2025, 7, 31), 5555, 100),
+	}
+	rule, _ := ParseRollRule("expiry", 2)
+
+	// Act
+	series, err := Continuous(bars, rule)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, series, 3)
+	assert.Equal(t, "WDOQ25", series[0].Contract.Code)
+	assert.Equal(t, "WDOU25", series[1].Contract.Code)
+	assert.True(t, series[1].Rolled)
+	assert.Equal(t, "WDOU25", series[2].Contract.Code)
+}
+
+func TestContinuousGivenUnknownRootWhenBuiltThenReturnsError(t *testing.T) {
+	// Arrange
+	rule, _ := ParseRollRule("volume", 0)
+
+	// Act
+	_, err := Continuous([]Bar{bar("XYZQ25", date(2025, 7, 29), 1, 1)}, rule)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownRoot)
+}
+
+func TestParseRollRuleGivenInvalidValuesWhenParsedThenReturnsError(t *testing.T) {
+	// Act
+	_, errMethod := ParseRollRule("calendar", 2)
+	_, errDays := ParseRollRule("expiry", -1)
+
+	// Assert
+	assert.Error(t, errMethod)
+	assert.Error(t, errDays)
+}
@@ -0,0 +1,253 @@
+// Package indicators computes technical indicators over the daily bars of a ticker.
+package indicators
+
+import (
+	"b3-ingest/internal/domain/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Kind names an indicator.
+type Kind string
+
+const (
+	SMA        Kind = "sma"
+	EMA        Kind = "ema"
+	RSI        Kind = "rsi"
+	Bollinger  Kind = "bollinger"
+	ATR        Kind = "atr"
+	Volatility Kind = "volatility"
+	ADV        Kind = "adv"
+)
+
+// defaultWindows are the windows used when none is given, in sessions.
+var defaultWindows = map[Kind]int{
+	SMA:        20,
+	EMA:        20,
+	RSI:        14,
+	Bollinger:  20,
+	ATR:        14,
+	Volatility: 21,
+	ADV:        20,
+}
+
+const (
+	// bollingerWidth is the number of standard deviations between the middle and outer bands.
+	bollingerWidth = 2
+	// tradingDaysPerYear annualizes the volatility of daily log returns.
+	tradingDaysPerYear = 252
+	// smoothingWarmup is how many windows of extra history the recursive indicators get, so the
+	// weight left on their seed is negligible by the first requested session.
+	smoothingWarmup = 3
+)
+
+// Point is the value of an indicator on one session. Value is nil while the window is not full;
+// Upper and Lower are only set for Bollinger bands, whose middle band is Value.
+type Point struct {
+	Date  time.Time
+	Value *float64
+	Upper *float64
+	Lower *float64
+}
+
+// ParseKind validates an indicator name and returns it with its default window.
+func ParseKind(name string) (Kind, int, error) {
+	window, ok := defaultWindows[Kind(name)]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown indicator %q, use sma, ema, rsi, bollinger, atr, volatility or adv", name)
+	}
+	return Kind(name), window, nil
+}
+
+// Warmup is the number of sessions before the first requested one needed to compute it.
+func Warmup(kind Kind, window int) int {
+	switch kind {
+	case EMA:
+		return window - 1 + smoothingWarmup*window
+	case RSI, ATR:
+		return window + smoothingWarmup*window
+	case Volatility:
+		return window
+	default:
+		return window - 1
+	}
+}
+
+// Compute returns one point per bar, in the same order. bars must be consecutive sessions
+// ordered by date.
+func Compute(kind Kind, window int, bars []models.DailySummary) []Point {
+	points := make([]Point, len(bars))
+	for i, b := range bars {
+		points[i].Date = b.Date
+	}
+	if window < 1 {
+		return points
+	}
+	closes := make([]float64, len(bars))
+	volumes := make([]float64, len(bars))
+	for i, b := range bars {
+		closes[i] = b.Close
+		volumes[i] = float64(b.Volume)
+	}
+	switch kind {
+	case SMA:
+		fill(points, sma(closes, window))
+	case EMA:
+		fill(points, ema(closes, window))
+	case RSI:
+		fill(points, rsi(closes, window))
+	case ATR:
+		fill(points, atr(bars, window))
+	case Volatility:
+		fill(points, volatility(closes, window))
+	case ADV:
+		fill(points, sma(volumes, window))
+	case Bollinger:
+		middle := sma(closes, window)
+		fill(points, middle)
+		for i := window - 1; i < len(closes); i++ {
+			d := bollingerWidth * stddev(closes[i-window+1:i+1], false)
+			upper, lower := middle[i]+d, middle[i]-d
+			points[i].Upper, points[i].Lower = &upper, &lower
+		}
+	}
+	return points
+}
+
+// fill copies the non-NaN values into points; NaN marks a session without a full window.
+func fill(points []Point, values []float64) {
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			v := v
+			points[i].Value = &v
+		}
+	}
+}
+
+// series returns a slice of n NaNs.
+func series(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
+
+func sma(values []float64, window int) []float64 {
+	out := series(len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		if i >= window-1 {
+			out[i] = sum / float64(window)
+		}
+	}
+	return out
+}
+
+// ema is seeded with the simple average of the first window values.
+func ema(values []float64, window int) []float64 {
+	out := series(len(values))
+	if len(values) < window {
+		return out
+	}
+	alpha := 2 / float64(window+1)
+	prev := sma(values[:window], window)[window-1]
+	out[window-1] = prev
+	for i := window; i < len(values); i++ {
+		prev = alpha*values[i] + (1-alpha)*prev
+		out[i] = prev
+	}
+	return out
+}
+
+// wilder smooths values[1:] the way RSI and ATR do: the first output, at index window, is the
+// simple average of values[1..window] and each later one moves 1/window towards the new value.
+func wilder(values []float64, window int) []float64 {
+	out := series(len(values))
+	if len(values) <= window {
+		return out
+	}
+	avg := 0.0
+	for i := 1; i <= window; i++ {
+		avg += values[i]
+	}
+	avg /= float64(window)
+	out[window] = avg
+	for i := window + 1; i < len(values); i++ {
+		avg = (avg*float64(window-1) + values[i]) / float64(window)
+		out[i] = avg
+	}
+	return out
+}
+
+// rsi is Wilder's relative strength index of the close-to-close changes.
+func rsi(closes []float64, window int) []float64 {
+	gains := make([]float64, len(closes))
+	losses := make([]float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		if d := closes[i] - closes[i-1]; d > 0 {
+			gains[i] = d
+		} else {
+			losses[i] = -d
+		}
+	}
+	avgGain, avgLoss := wilder(gains, window), wilder(losses, window)
+	out := series(len(closes))
+	for i := range closes {
+		switch {
+		case math.IsNaN(avgGain[i]):
+		case avgLoss[i] == 0:
+			out[i] = 100
+		default:
+			out[i] = 100 - 100/(1+avgGain[i]/avgLoss[i])
+		}
+	}
+	return out
+}
+
+// atr is Wilder's average true range; the true range needs the previous close, so it starts on
+// the second session.
+func atr(bars []models.DailySummary, window int) []float64 {
+	ranges := make([]float64, len(bars))
+	for i := 1; i < len(bars); i++ {
+		prev := bars[i-1].Close
+		ranges[i] = math.Max(bars[i].High-bars[i].Low, math.Max(math.Abs(bars[i].High-prev), math.Abs(bars[i].Low-prev)))
+	}
+	return wilder(ranges, window)
+}
+
+// volatility is the annualized sample standard deviation of the last window daily log returns.
+func volatility(closes []float64, window int) []float64 {
+	out := series(len(closes))
+	returns := make([]float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		returns[i] = math.Log(closes[i] / closes[i-1])
+	}
+	for i := window; i < len(closes); i++ {
+		out[i] = stddev(returns[i-window+1:i+1], true) * math.Sqrt(tradingDaysPerYear)
+	}
+	return out
+}
+
+// stddev is the population standard deviation of values, or the sample one when sample is set.
+func stddev(values []float64, sample bool) float64 {
+	n := float64(len(values))
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	sq := 0.0
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	if sample {
+		n--
+	}
+	return math.Sqrt(sq / n)
+}
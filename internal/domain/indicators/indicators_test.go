@@ -0,0 +1,157 @@
+package indicators
+
+import (
+	"b3-ingest/internal/domain/models"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// closeBars builds consecutive daily bars with the given closes and volumes.
+func closeBars(closes []float64, volumes ...int64) []models.DailySummary {
+	bars := make([]models.DailySummary, len(closes))
+	for i, c := range closes {
+		bars[i] = models.DailySummary{Date: time.Date(2025, 7, 1+i, 0, 0, 0, 0, time.UTC), Close: c}
+		if i < len(volumes) {
+			bars[i].Volume = volumes[i]
+		}
+	}
+	return bars
+}
+
+// values flattens points, with NaN for sessions without a value.
+func values(points []Point) []float64 {
+	out := make([]float64, len(points))
+	for i, p := range points {
+		out[i] = math.NaN()
+		if p.Value != nil {
+			out[i] = *p.Value
+		}
+	}
+	return out
+}
+
+func assertSeries(t *testing.T, expected, got []float64) {
+	t.Helper()
+	assert.Len(t, got, len(expected))
+	for i := range expected {
+		if math.IsNaN(expected[i]) {
+			assert.True(t, math.IsNaN(got[i]), "index %d: expected no value, got %v", i, got[i])
+			continue
+		}
+		assert.InDelta(t, expected[i], got[i], 1e-6, "index %d", i)
+	}
+}
+
+var nan = math.NaN()
+
+func TestComputeGivenSMAWhenWindowIsNotFullThenLeavesValuesEmpty(t *testing.T) {
+	// Act
+	points := Compute(SMA, 3, closeBars([]float64{1, 2, 3, 4, 5}))
+
+	// Assert
+	assertSeries(t, []float64{nan, nan, 2, 3, 4}, values(points))
+}
+
+func TestComputeGivenEMAWhenComputedThenSeedsWithSMA(t *testing.T) {
+	// Act
+	points := Compute(EMA, 3, closeBars([]float64{1, 2, 3, 4, 5, 3}))
+
+	// Assert
+	// alpha = 2/(3+1) = 0.5: 2, then 0.5*4+0.5*2 = 3, 4, 3.5.
+	assertSeries(t, []float64{nan, nan, 2, 3, 4, 3.5}, values(points))
+}
+
+func TestComputeGivenWilderRSIExampleWhenComputedThenMatchesKnownValues(t *testing.T) {
+	// Arrange
+	closes := []float64{
+		44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+		45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+	}
+
+	// Act
+	got := values(Compute(RSI, 14, closeBars(closes)))
+
+	// Assert
+	assert.True(t, math.IsNaN(got[13]))
+	assert.InDelta(t, 70.4641, got[14], 1e-4)
+	assert.InDelta(t, 66.2496, got[15], 1e-4)
+	assert.InDelta(t, 66.4809, got[16], 1e-4)
+	assert.InDelta(t, 69.3469, got[17], 1e-4)
+	assert.InDelta(t, 66.2947, got[18], 1e-4)
+	assert.InDelta(t, 57.9150, got[19], 1e-4)
+}
+
+func TestComputeGivenOnlyGainsWhenRSIThenReturnsHundred(t *testing.T) {
+	// Act
+	got := values(Compute(RSI, 2, closeBars([]float64{1, 2, 3})))
+
+	// Assert
+	assertSeries(t, []float64{nan, nan, 100}, got)
+}
+
+func TestComputeGivenBollingerWhenComputedThenBandsAreTwoPopulationDeviationsAway(t *testing.T) {
+	// Act
+	points := Compute(Bollinger, 3, closeBars([]float64{1, 2, 3}))
+
+	// Assert
+	assert.Nil(t, points[1].Upper)
+	assert.InDelta(t, 2, *points[2].Value, 1e-9)
+	assert.InDelta(t, 2+2*math.Sqrt(2.0/3), *points[2].Upper, 1e-9)
+	assert.InDelta(t, 2-2*math.Sqrt(2.0/3), *points[2].Lower, 1e-9)
+}
+
+func TestComputeGivenATRWhenComputedThenUsesTrueRangeAndWilderSmoothing(t *testing.T) {
+	// Arrange
+	bars := []models.DailySummary{
+		{High: 10, Low: 9, Close: 9.5},
+		{High: 11, Low: 9.5, Close: 10.5},
+		{High: 10.8, Low: 10, Close: 10.2},
+		{High: 12, Low: 10.1, Close: 11.8},
+		{High: 11.9, Low: 11, Close: 11.2},
+	}
+
+	// Act
+	got := values(Compute(ATR, 2, bars))
+
+	// Assert
+	// True ranges 1.5, 0.8 (gap to the 10.5 close), 1.9 and 0.9.
+	assertSeries(t, []float64{nan, nan, 1.15, 1.525, 1.2125}, got)
+}
+
+func TestComputeGivenVolatilityWhenComputedThenAnnualizesSampleDeviationOfLogReturns(t *testing.T) {
+	// Act
+	got := values(Compute(Volatility, 2, closeBars([]float64{100, 110, 99})))
+
+	// Assert
+	assertSeries(t, []float64{nan, nan, 2.252523}, got)
+}
+
+func TestComputeGivenADVWhenComputedThenAveragesVolume(t *testing.T) {
+	// Act
+	got := values(Compute(ADV, 2, closeBars([]float64{1, 1, 1}, 100, 300, 200)))
+
+	// Assert
+	assertSeries(t, []float64{nan, 200, 250}, got)
+}
+
+func TestWarmupGivenWindowWhenCalledThenCoversFirstFullWindow(t *testing.T) {
+	// Assert
+	assert.Equal(t, 19, Warmup(SMA, 20))
+	assert.Equal(t, 21, Warmup(Volatility, 21))
+	assert.Equal(t, 14+3*14, Warmup(RSI, 14))
+}
+
+func TestParseKindGivenNamesWhenParsedThenReturnsDefaultWindow(t *testing.T) {
+	// Act
+	kind, window, err := ParseKind("rsi")
+	_, _, unknownErr := ParseKind("macd")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, RSI, kind)
+	assert.Equal(t, 14, window)
+	assert.Error(t, unknownErr)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Reasons a trade is flagged as a block trade.
+const (
+	BlockReasonMedian    = "median"
+	BlockReasonThreshold = "threshold"
+)
+
+// BlockTradeRule flags a trade whose quantity exceeds Multiple times the rolling median trade size
+// of its ticker, or reaches MinQuantity. The rolling median is the median of the daily median trade
+// sizes of the last Window sessions before the trade's. A zero Multiple or MinQuantity disables
+// that test.
+type BlockTradeRule struct {
+	Multiple    float64
+	MinQuantity int64
+	Window      int
+}
+
+// Enabled reports whether the rule can flag any trade.
+func (r BlockTradeRule) Enabled() bool {
+	return r.Multiple > 0 || r.MinQuantity > 0
+}
+
+func (r BlockTradeRule) Validate() error {
+	if r.Multiple < 0 || r.MinQuantity < 0 {
+		return errors.New("block trade multiple and minimum quantity must not be negative")
+	}
+	if r.Multiple > 0 && r.Window < 1 {
+		return errors.New("block trade window must be at least one session")
+	}
+	return nil
+}
+
+// BlockTrade is a flagged trade with the rolling median it was compared with. Reason is the test
+// that flagged it; a trade passing both is reported as a threshold trade. MedianQuantity is nil on
+// the first session of a ticker, which has no earlier sessions to take a median from.
+type BlockTrade struct {
+	Ticker string
+	Trade
+	MedianQuantity *float64
+	Reason         string
+}
+
+// BlockTradeFilter selects a page of block trades. Empty strings, nil dates and zero quantities do
+// not filter. The date bounds are inclusive.
+type BlockTradeFilter struct {
+	Ticker      string
+	Prefix      string
+	From        *time.Time
+	To          *time.Time
+	MinQuantity int64
+	Reason      string
+	Limit       int
+	Offset      int
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockTradeRuleGivenSettingsWhenValidatedThenRejectsInconsistentRules(t *testing.T) {
+	// Assert
+	assert.NoError(t, BlockTradeRule{Multiple: 20, Window: 20}.Validate())
+	assert.NoError(t, BlockTradeRule{MinQuantity: 100000}.Validate())
+	assert.NoError(t, BlockTradeRule{}.Validate())
+	assert.Error(t, BlockTradeRule{Multiple: -1, Window: 20}.Validate())
+	assert.Error(t, BlockTradeRule{Multiple: 20}.Validate())
+}
+
+func TestBlockTradeRuleGivenZeroSettingsWhenCheckedThenIsDisabled(t *testing.T) {
+	// Assert
+	assert.False(t, BlockTradeRule{Window: 20}.Enabled())
+	assert.True(t, BlockTradeRule{MinQuantity: 1}.Enabled())
+}
@@ -0,0 +1,68 @@
+package models
+
+import "sort"
+
+// Broker is a market participant of B3 and the name it trades under.
+type Broker struct {
+	Code int
+	Name string
+}
+
+// BrokerFlow is what a participant bought and sold of a ticker over a period. Name is empty when
+// the code has no entry in the broker mapping.
+type BrokerFlow struct {
+	Code         int
+	Name         string
+	BuyQuantity  int64
+	SellQuantity int64
+	BuyNotional  float64
+	SellNotional float64
+	BuyTrades    int64
+	SellTrades   int64
+}
+
+// NetQuantity is the quantity bought minus the quantity sold.
+func (f BrokerFlow) NetQuantity() int64 {
+	return f.BuyQuantity - f.SellQuantity
+}
+
+// NetNotional is the notional bought minus the notional sold.
+func (f BrokerFlow) NetNotional() float64 {
+	return f.BuyNotional - f.SellNotional
+}
+
+// BrokerFlowReport holds the flow of every participant of a ticker, ordered by code, and the ones
+// with the largest net quantity bought and sold.
+type BrokerFlowReport struct {
+	Brokers    []BrokerFlow
+	TopBuyers  []BrokerFlow
+	TopSellers []BrokerFlow
+}
+
+// NewBrokerFlowReport builds the report of flows, which must be ordered by code. TopBuyers holds
+// up to top participants with a positive net quantity, largest first, and TopSellers up to top
+// with a negative one, most sold first. Ties are broken by code.
+func NewBrokerFlowReport(flows []BrokerFlow, top int) BrokerFlowReport {
+	report := BrokerFlowReport{Brokers: flows, TopBuyers: []BrokerFlow{}, TopSellers: []BrokerFlow{}}
+	for _, f := range flows {
+		switch {
+		case f.NetQuantity() > 0:
+			report.TopBuyers = append(report.TopBuyers, f)
+		case f.NetQuantity() < 0:
+			report.TopSellers = append(report.TopSellers, f)
+		}
+	}
+	sort.SliceStable(report.TopBuyers, func(i, j int) bool {
+		return report.TopBuyers[i].NetQuantity() > report.TopBuyers[j].NetQuantity()
+	})
+	sort.SliceStable(report.TopSellers, func(i, j int) bool {
+		return report.TopSellers[i].NetQuantity() < report.TopSellers[j].NetQuantity()
+	})
+	if len(report.TopBuyers) > top {
+		report.TopBuyers = report.TopBuyers[:top]
+	}
+	if len(report.TopSellers) > top {
+		report.TopSellers = report.TopSellers[:top]
+	}
+	return report
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBrokerFlowReportGivenFlowsWhenCalledThenRanksTopBuyersAndSellers(t *testing.T) {
+	// Arrange
+	flows := []BrokerFlow{
+		{Code: 3, BuyQuantity: 500, SellQuantity: 100},
+		{Code: 8, BuyQuantity: 100, SellQuantity: 900},
+		{Code: 15, BuyQuantity: 200, SellQuantity: 200},
+		{Code: 27, BuyQuantity: 700, SellQuantity: 300},
+		{Code: 40, BuyQuantity: 0, SellQuantity: 100},
+		{Code: 72, BuyQuantity: 900, SellQuantity: 0},
+	}
+
+	// Act
+	report := NewBrokerFlowReport(flows, 2)
+
+	// Assert
+	assert.Len(t, report.Brokers, 6)
+	assert.Equal(t, []int{72, 3}, codes(report.TopBuyers))
+	assert.Equal(t, []int{8, 40}, codes(report.TopSellers))
+	assert.Equal(t, int64(-800), report.TopSellers[0].NetQuantity())
+}
+
+func TestNewBrokerFlowReportGivenNoFlowsWhenCalledThenReturnsEmptyLists(t *testing.T) {
+	// Act
+	report := NewBrokerFlowReport(nil, 5)
+
+	// Assert
+	assert.Empty(t, report.TopBuyers)
+	assert.NotNil(t, report.TopBuyers)
+	assert.NotNil(t, report.TopSellers)
+}
+
+func TestBrokerFlowGivenTradesWhenNetNotionalThenSubtractsSales(t *testing.T) {
+	// Arrange
+	flow := BrokerFlow{BuyNotional: 3210.5, SellNotional: 1000}
+
+	// Act
+	net := flow.NetNotional()
+
+	// Assert
+	assert.InDelta(t, 2210.5, net, 1e-9)
+}
+
+func codes(flows []BrokerFlow) []int {
+	out := make([]int, 0, len(flows))
+	for _, f := range flows {
+		out = append(out, f.Code)
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Candle is the OHLCV summary of the trades of a ticker within one time bucket.
+type Candle struct {
+	Start  time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+	Trades int64
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// SaoPaulo is the time zone of the B3 trading session. It falls back to a fixed UTC-3 offset,
+// which has been Brazil's legal time since daylight saving was abolished in 2019, when the
+// tz database is not available.
+var SaoPaulo = loadSaoPaulo()
+
+func loadSaoPaulo() *time.Location {
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		return time.FixedZone("BRT", -3*60*60)
+	}
+	return loc
+}
+
+// SessionTime combines a trading date with a B3 time of day encoded as HHMMSSmmm,
+// such as the hora_fechamento column, into an instant in Sao Paulo time.
+func SessionTime(date time.Time, hhmmssmmm int64) time.Time {
+	h := hhmmssmmm / 10000000
+	m := hhmmssmmm / 100000 % 100
+	s := hhmmssmmm / 1000 % 100
+	ms := hhmmssmmm % 1000
+	y, mo, d := date.Date()
+	return time.Date(y, mo, d, int(h), int(m), int(s), int(ms)*int(time.Millisecond), SaoPaulo)
+}
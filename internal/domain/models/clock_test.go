@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionTimeGivenHHMMSSmmmWhenCombinedWithDateThenReturnsSaoPauloInstant(t *testing.T) {
+	// Arrange
+	date := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	got := SessionTime(date, 93015250)
+
+	// Assert
+	assert.Equal(t, time.Date(2025, 7, 29, 12, 30, 15, 250*int(time.Millisecond), time.UTC), got.UTC())
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DailySummary is the daily OHLCV bar of a ticker together with its return over the previous session.
+// Return is nil when there is no earlier session to compare with.
+type DailySummary struct {
+	Date   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+	Trades int64
+	VWAP   float64
+	Return *float64
+}
@@ -0,0 +1,11 @@
+package models
+
+import "errors"
+
+// ErrTickerNotFound is returned when a ticker has never been traded, as opposed to a known
+// ticker without trades in the requested range.
+var ErrTickerNotFound = errors.New("ticker not found")
+
+// ErrTooManyPriceLevels is returned when a tick size splits a volume profile into more than
+// MaxPriceLevels levels.
+var ErrTooManyPriceLevels = errors.New("too many price levels")
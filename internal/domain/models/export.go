@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "csv"
+	ExportNDJSON  ExportFormat = "ndjson"
+	ExportParquet ExportFormat = "parquet"
+)
+
+// Valid reports whether f is a supported format.
+func (f ExportFormat) Valid() bool {
+	switch f {
+	case ExportCSV, ExportNDJSON, ExportParquet:
+		return true
+	}
+	return false
+}
+
+// ContentType is the media type served for f.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	case ExportParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv"
+	}
+}
+
+// ExportDataset is the data an export contains.
+type ExportDataset string
+
+const (
+	ExportTrades    ExportDataset = "trades"
+	ExportDailyBars ExportDataset = "daily"
+)
+
+// Valid reports whether d is a supported dataset.
+func (d ExportDataset) Valid() bool {
+	return d == ExportTrades || d == ExportDailyBars
+}
+
+// ExportRequest selects the rows of an export: the trades or daily bars of Ticker between From
+// and To, both inclusive.
+type ExportRequest struct {
+	Ticker  string
+	From    time.Time
+	To      time.Time
+	Dataset ExportDataset
+	Format  ExportFormat
+}
+
+// FileName is the suggested name of the exported file, such as PETR4_daily_2025-07-01_2025-07-31.csv.
+func (r ExportRequest) FileName() string {
+	return r.Ticker + "_" + string(r.Dataset) + "_" + r.From.Format("2006-01-02") + "_" + r.To.Format("2006-01-02") + "." + string(r.Format)
+}
+
+// NewExportRequest validates the raw parameters of an export. Dates use the YYYY-MM-DD format,
+// and an empty dataset or format selects daily bars as CSV.
+func NewExportRequest(ticker, from, to, dataset, format string) (ExportRequest, error) {
+	req := ExportRequest{Ticker: ticker, Dataset: ExportDataset(dataset), Format: ExportFormat(format)}
+	if req.Dataset == "" {
+		req.Dataset = ExportDailyBars
+	}
+	if req.Format == "" {
+		req.Format = ExportCSV
+	}
+	if req.Ticker == "" {
+		return req, errors.New("ticker is required")
+	}
+	if !req.Dataset.Valid() {
+		return req, errors.New("dataset must be trades or daily")
+	}
+	if !req.Format.Valid() {
+		return req, errors.New("format must be csv, ndjson or parquet")
+	}
+	var err error
+	if req.From, err = time.Parse("2006-01-02", from); err != nil {
+		return req, errors.New("from is required, use format YYYY-MM-DD")
+	}
+	if req.To, err = time.Parse("2006-01-02", to); err != nil {
+		return req, errors.New("to is required, use format YYYY-MM-DD")
+	}
+	if req.From.After(req.To) {
+		return req, errors.New("from must not be after to")
+	}
+	return req, nil
+}
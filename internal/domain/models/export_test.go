@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExportRequestGivenNoDatasetOrFormatWhenCreatedThenDefaultsToDailyCSV(t *testing.T) {
+	// Act
+	req, err := NewExportRequest("PETR4", "2025-07-01", "2025-07-31", "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, ExportDailyBars, req.Dataset)
+	assert.Equal(t, ExportCSV, req.Format)
+	assert.Equal(t, time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), req.To)
+	assert.Equal(t, "PETR4_daily_2025-07-01_2025-07-31.csv", req.FileName())
+}
+
+func TestNewExportRequestGivenInvalidParamsWhenCreatedThenReturnsError(t *testing.T) {
+	cases := [][5]string{
+		{"", "2025-07-01", "2025-07-31", "trades", "csv"},
+		{"PETR4", "2025-07-01", "2025-07-31", "quotes", "csv"},
+		{"PETR4", "2025-07-01", "2025-07-31", "trades", "xlsx"},
+		{"PETR4", "", "2025-07-31", "trades", "csv"},
+		{"PETR4", "2025-08-01", "2025-07-31", "trades", "csv"},
+	}
+	for _, c := range cases {
+		// Act
+		_, err := NewExportRequest(c[0], c[1], c[2], c[3], c[4])
+
+		// Assert
+		assert.Error(t, err, c)
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"time"
+)
+
+// Instrument is an entry of the ticker catalog.
+type Instrument struct {
+	Ticker         string
+	FirstTradeDate time.Time
+	LastTradeDate  time.Time
+	TotalTrades    int64
+	// Class is empty until the ingestion has classified the ticker.
+	Class classifier.Classification
+}
+
+// InstrumentFilter selects a page of the ticker catalog. Empty strings and nil dates do not filter.
+// The date bounds are inclusive.
+type InstrumentFilter struct {
+	Prefix         string
+	Contains       string
+	AssetClass     string
+	Root           string
+	FirstTradeFrom *time.Time
+	FirstTradeTo   *time.Time
+	LastTradeFrom  *time.Time
+	LastTradeTo    *time.Time
+	Limit          int
+	Offset         int
+}
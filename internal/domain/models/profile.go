@@ -0,0 +1,68 @@
+package models
+
+// ValueAreaShare is the share of the volume traded inside the value area.
+const ValueAreaShare = 0.70
+
+// MaxPriceLevels bounds the levels of a volume profile, so a tick size far below the price range
+// cannot return an unbounded number of levels.
+const MaxPriceLevels = 5000
+
+// PriceLevel is the volume traded at one price bin of a volume profile.
+type PriceLevel struct {
+	Price  float64
+	Volume int64
+	Trades int64
+}
+
+// VolumeProfile is the volume traded per price level over a range, with its point of control (the
+// level with the most volume) and value area. Levels are ordered by price.
+type VolumeProfile struct {
+	Levels          []PriceLevel
+	TotalVolume     int64
+	POC             float64
+	ValueAreaLow    float64
+	ValueAreaHigh   float64
+	ValueAreaVolume int64
+}
+
+// NewVolumeProfile computes the point of control and value area of levels, which must be ordered
+// by price. The value area grows from the point of control one level at a time, towards the
+// neighbour with more volume, until it holds ValueAreaShare of the total. Ties go to the lower
+// price for the point of control and to the upper neighbour while growing.
+func NewVolumeProfile(levels []PriceLevel) VolumeProfile {
+	p := VolumeProfile{Levels: levels}
+	if len(levels) == 0 {
+		return p
+	}
+	poc := 0
+	for i, l := range levels {
+		p.TotalVolume += l.Volume
+		if l.Volume > levels[poc].Volume {
+			poc = i
+		}
+	}
+	lo, hi := poc, poc
+	volume := levels[poc].Volume
+	target := ValueAreaShare * float64(p.TotalVolume)
+	for float64(volume) < target && (lo > 0 || hi < len(levels)-1) {
+		up, down := int64(-1), int64(-1)
+		if hi < len(levels)-1 {
+			up = levels[hi+1].Volume
+		}
+		if lo > 0 {
+			down = levels[lo-1].Volume
+		}
+		if up >= down {
+			hi++
+			volume += up
+		} else {
+			lo--
+			volume += down
+		}
+	}
+	p.POC = levels[poc].Price
+	p.ValueAreaLow = levels[lo].Price
+	p.ValueAreaHigh = levels[hi].Price
+	p.ValueAreaVolume = volume
+	return p
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVolumeProfileGivenLevelsWhenComputedThenReturnsPOCAndValueArea(t *testing.T) {
+	// Arrange
+	levels := []PriceLevel{
+		{Price: 10.0, Volume: 5},
+		{Price: 10.5, Volume: 10},
+		{Price: 11.0, Volume: 40},
+		{Price: 11.5, Volume: 20},
+		{Price: 12.0, Volume: 15},
+		{Price: 12.5, Volume: 10},
+	}
+
+	// Act
+	p := NewVolumeProfile(levels)
+
+	// Assert
+	assert.Equal(t, int64(100), p.TotalVolume)
+	assert.Equal(t, 11.0, p.POC)
+	// 40, then 20 above, then 15 above reach 75 of 100.
+	assert.Equal(t, 11.0, p.ValueAreaLow)
+	assert.Equal(t, 12.0, p.ValueAreaHigh)
+	assert.Equal(t, int64(75), p.ValueAreaVolume)
+}
+
+func TestNewVolumeProfileGivenLargerVolumeBelowWhenComputedThenGrowsDownwards(t *testing.T) {
+	// Arrange
+	levels := []PriceLevel{
+		{Price: 1, Volume: 30},
+		{Price: 2, Volume: 50},
+		{Price: 3, Volume: 20},
+	}
+
+	// Act
+	p := NewVolumeProfile(levels)
+
+	// Assert
+	assert.Equal(t, 2.0, p.POC)
+	assert.Equal(t, 1.0, p.ValueAreaLow)
+	assert.Equal(t, 2.0, p.ValueAreaHigh)
+	assert.Equal(t, int64(80), p.ValueAreaVolume)
+}
+
+func TestNewVolumeProfileGivenNoLevelsWhenComputedThenReturnsEmptyProfile(t *testing.T) {
+	// Act
+	p := NewVolumeProfile(nil)
+
+	// Assert
+	assert.Zero(t, p.TotalVolume)
+	assert.Empty(t, p.Levels)
+}
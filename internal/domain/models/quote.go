@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Quote summarizes the daily bars of a ticker over a date range. VWAP is weighted by the volume
+// of each session. A range without trades has zero Sessions, and then MaxPriceDate and
+// MinPriceDate are nil.
+type Quote struct {
+	Ticker         string
+	Sessions       int64
+	MaxPrice       float64
+	MinPrice       float64
+	OpenPrice      float64
+	ClosePrice     float64
+	VWAP           float64
+	MaxDailyVolume int64
+	TotalVolume    int64
+	TradeCount     int64
+	MaxPriceDate   *time.Time
+	MinPriceDate   *time.Time
+}
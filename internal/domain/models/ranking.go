@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// RankingMetric is the measure instruments are ranked by.
+type RankingMetric string
+
+const (
+	// RankByVolume ranks by the quantity traded in the range.
+	RankByVolume RankingMetric = "volume"
+	// RankByTrades ranks by the number of trades in the range.
+	RankByTrades RankingMetric = "trades"
+	// RankByReturn ranks by the last close of the range over the close of the session before it.
+	RankByReturn RankingMetric = "return"
+	// RankByRange ranks by the highest high over the lowest low of the range, minus one.
+	RankByRange RankingMetric = "range"
+)
+
+func (m RankingMetric) Valid() bool {
+	switch m {
+	case RankByVolume, RankByTrades, RankByReturn, RankByRange:
+		return true
+	}
+	return false
+}
+
+// RankingQuery selects the instruments to rank. Empty AssetClass and Prefix do not filter.
+type RankingQuery struct {
+	Metric     RankingMetric
+	From       time.Time
+	To         time.Time
+	AssetClass string
+	Prefix     string
+	Limit      int
+}
+
+// RankingEntry is one ranked instrument; Close is its last close in the range.
+type RankingEntry struct {
+	Ticker string
+	Value  float64
+	Volume int64
+	Trades int64
+	Close  float64
+}
+
+// Rankings holds the Limit instruments with the highest and the lowest values, best first and
+// worst first respectively.
+type Rankings struct {
+	Top    []RankingEntry
+	Bottom []RankingEntry
+}
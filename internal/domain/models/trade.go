@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Trade is a single trade of a ticker. ClosingTime is the hora_fechamento published by B3,
+// encoded as HHMMSSmmm.
+type Trade struct {
+	Date        time.Time
+	ClosingTime int64
+	Price       float64
+	Quantity    int64
+	TradeID     int64
+}
+
+// TradeCursor is the position of a trade in the (date, closing time, trade id) order used to
+// page through trades.
+type TradeCursor struct {
+	Date        time.Time
+	ClosingTime int64
+	TradeID     int64
+}
+
+// Cursor returns the position of t.
+func (t Trade) Cursor() TradeCursor {
+	return TradeCursor{Date: t.Date, ClosingTime: t.ClosingTime, TradeID: t.TradeID}
+}
+
+// TradeQuery selects the trades of Ticker between From and To, both inclusive, with at least
+// MinQuantity shares. When After is set only the trades after that position are returned.
+type TradeQuery struct {
+	Ticker      string
+	From        time.Time
+	To          time.Time
+	MinQuantity int64
+	After       *TradeCursor
+	Limit       int
+}
+
+// TradePage is a page of trades. Next is nil on the last page.
+type TradePage struct {
+	Trades []Trade
+	Next   *TradeCursor
+}
@@ -0,0 +1,95 @@
+package quality
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// document is the machine-readable form of a Report, served by /v1/quality and written by -check.
+// OK is set when no check found anything.
+type document struct {
+	DataInicio      string                   `json:"data_inicio"`
+	DataFim         string                   `json:"data_fim"`
+	DuplicatesFrom  string                   `json:"duplicates_from"`
+	OK              bool                     `json:"ok"`
+	ExpectedDays    int                      `json:"expected_days"`
+	LoadedDays      int                      `json:"loaded_days"`
+	MissingDates    []string                 `json:"missing_dates"`
+	UnexpectedDates []string                 `json:"unexpected_dates"`
+	RowAnomalies    []rowAnomalyDocument     `json:"row_anomalies"`
+	PriceJumps      []priceJumpDocument      `json:"price_jumps"`
+	DuplicateTrades []duplicateTradeDocument `json:"duplicate_trades"`
+}
+
+type rowAnomalyDocument struct {
+	Date            string  `json:"date"`
+	Rows            int64   `json:"rows"`
+	TrailingAverage float64 `json:"trailing_average"`
+	Deviation       float64 `json:"deviation"`
+}
+
+type priceJumpDocument struct {
+	Ticker        string  `json:"ticker"`
+	Date          string  `json:"date"`
+	PreviousClose float64 `json:"previous_close"`
+	Close         float64 `json:"close"`
+	Change        float64 `json:"change"`
+}
+
+type duplicateTradeDocument struct {
+	Ticker  string `json:"ticker"`
+	Date    string `json:"date"`
+	TradeID int64  `json:"trade_id"`
+	Count   int64  `json:"count"`
+}
+
+// MarshalJSON writes the report with dates as YYYY-MM-DD and empty lists instead of null.
+func (r Report) MarshalJSON() ([]byte, error) {
+	doc := document{
+		DataInicio:      r.From.Format("2006-01-02"),
+		DataFim:         r.To.Format("2006-01-02"),
+		DuplicatesFrom:  r.DuplicatesFrom.Format("2006-01-02"),
+		OK:              r.Clean(),
+		ExpectedDays:    r.ExpectedDays,
+		LoadedDays:      r.LoadedDays,
+		MissingDates:    formatDates(r.MissingDates),
+		UnexpectedDates: formatDates(r.UnexpectedDates),
+		RowAnomalies:    make([]rowAnomalyDocument, 0, len(r.RowAnomalies)),
+		PriceJumps:      make([]priceJumpDocument, 0, len(r.PriceJumps)),
+		DuplicateTrades: make([]duplicateTradeDocument, 0, len(r.DuplicateTrades)),
+	}
+	for _, a := range r.RowAnomalies {
+		doc.RowAnomalies = append(doc.RowAnomalies, rowAnomalyDocument{
+			Date:            a.Date.Format("2006-01-02"),
+			Rows:            a.Rows,
+			TrailingAverage: a.TrailingAverage,
+			Deviation:       a.Deviation,
+		})
+	}
+	for _, j := range r.PriceJumps {
+		doc.PriceJumps = append(doc.PriceJumps, priceJumpDocument{
+			Ticker:        j.Ticker,
+			Date:          j.Date.Format("2006-01-02"),
+			PreviousClose: j.PreviousClose,
+			Close:         j.Close,
+			Change:        j.Change,
+		})
+	}
+	for _, d := range r.DuplicateTrades {
+		doc.DuplicateTrades = append(doc.DuplicateTrades, duplicateTradeDocument{
+			Ticker:  d.Ticker,
+			Date:    d.Date.Format("2006-01-02"),
+			TradeID: d.TradeID,
+			Count:   d.Count,
+		})
+	}
+	return json.Marshal(doc)
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, 0, len(dates))
+	for _, d := range dates {
+		out = append(out, d.Format("2006-01-02"))
+	}
+	return out
+}
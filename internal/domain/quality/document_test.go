@@ -0,0 +1,38 @@
+package quality
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportMarshalJSONGivenIssuesWhenMarshaledThenWritesDocument(t *testing.T) {
+	// Arrange
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	report := Report{
+		From:           day.AddDate(0, 0, -1),
+		To:             day,
+		DuplicatesFrom: day.AddDate(0, 0, -1),
+		ExpectedDays:   2,
+		LoadedDays:     1,
+		MissingDates:   []time.Time{day},
+		PriceJumps:     []PriceJump{{Ticker: "PETR4", Date: day, PreviousClose: 32, Close: 16, Change: -0.5}},
+	}
+
+	// Act
+	data, err := json.Marshal(report)
+
+	// Assert
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "2025-07-28", decoded["data_inicio"])
+	assert.Equal(t, "2025-07-28", decoded["duplicates_from"])
+	assert.Equal(t, false, decoded["ok"])
+	assert.Equal(t, []interface{}{"2025-07-29"}, decoded["missing_dates"])
+	assert.Equal(t, []interface{}{}, decoded["unexpected_dates"])
+	assert.Equal(t, "PETR4", decoded["price_jumps"].([]interface{})[0].(map[string]interface{})["ticker"])
+	assert.Contains(t, string(data), `"duplicate_trades":[]`)
+}
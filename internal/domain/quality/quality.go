@@ -0,0 +1,158 @@
+// Package quality checks the loaded trades against the B3 trading calendar and looks for days,
+// prices and trade ids that suggest a file was missed or only partly loaded.
+package quality
+
+import (
+	"b3-ingest/internal/domain/calendar"
+	"errors"
+	"math"
+	"time"
+)
+
+// minTrailingDays is the fewest earlier sessions a day's row count is compared with.
+const minTrailingDays = 5
+
+// Options holds the thresholds of a check.
+type Options struct {
+	// TrailingDays is the number of earlier loaded sessions a day's row count is averaged over.
+	TrailingDays int
+	// RowDeviation flags days whose row count differs from the trailing average by more than this
+	// fraction of it.
+	RowDeviation float64
+	// PriceJump flags closes that moved more than this fraction from the previous close.
+	PriceJump float64
+}
+
+func (o Options) Validate() error {
+	if o.TrailingDays < 1 {
+		return errors.New("trailing days must be at least one session")
+	}
+	if o.RowDeviation <= 0 || o.PriceJump <= 0 {
+		return errors.New("row deviation and price jump must be positive")
+	}
+	return nil
+}
+
+// DayCount is the number of trades loaded for one session.
+type DayCount struct {
+	Date time.Time
+	Rows int64
+}
+
+// RowAnomaly is a session whose row count is far from its trailing average. Deviation is the row
+// count over the average, minus one.
+type RowAnomaly struct {
+	Date            time.Time
+	Rows            int64
+	TrailingAverage float64
+	Deviation       float64
+}
+
+// PriceJump is a close that moved more than the threshold from the previous one. Change is the
+// close over the previous close, minus one.
+type PriceJump struct {
+	Ticker        string
+	Date          time.Time
+	PreviousClose float64
+	Close         float64
+	Change        float64
+}
+
+// DuplicateTrade is a trade id found more than once for a ticker and session.
+type DuplicateTrade struct {
+	Ticker  string
+	Date    time.Time
+	TradeID int64
+	Count   int64
+}
+
+// Report is the outcome of a check between From and To. Duplicate trades are only looked for
+// from DuplicatesFrom on.
+type Report struct {
+	From            time.Time
+	To              time.Time
+	DuplicatesFrom  time.Time
+	ExpectedDays    int
+	LoadedDays      int
+	MissingDates    []time.Time
+	UnexpectedDates []time.Time
+	RowAnomalies    []RowAnomaly
+	PriceJumps      []PriceJump
+	DuplicateTrades []DuplicateTrade
+}
+
+// Clean reports whether the check found nothing.
+func (r Report) Clean() bool {
+	return len(r.MissingDates) == 0 && len(r.UnexpectedDates) == 0 && len(r.RowAnomalies) == 0 &&
+		len(r.PriceJumps) == 0 && len(r.DuplicateTrades) == 0
+}
+
+// LookbackStart is the first date whose row count Check needs to average the sessions from from on.
+func LookbackStart(from time.Time, opts Options) time.Time {
+	return calendar.AddTradingDays(from, -opts.TrailingDays)
+}
+
+// Check compares counts, ordered by date and starting at LookbackStart, with the trading calendar
+// between from and to. Price jumps and duplicate trades are found by the caller and only copied.
+func Check(from, to time.Time, opts Options, counts []DayCount, jumps []PriceJump, duplicates []DuplicateTrade) Report {
+	report := Report{
+		From:            from,
+		To:              to,
+		DuplicatesFrom:  from,
+		MissingDates:    []time.Time{},
+		UnexpectedDates: []time.Time{},
+		RowAnomalies:    []RowAnomaly{},
+		PriceJumps:      jumps,
+		DuplicateTrades: duplicates,
+	}
+	if report.PriceJumps == nil {
+		report.PriceJumps = []PriceJump{}
+	}
+	if report.DuplicateTrades == nil {
+		report.DuplicateTrades = []DuplicateTrade{}
+	}
+
+	loaded := map[time.Time]bool{}
+	for i, c := range counts {
+		if c.Date.Before(from) || c.Date.After(to) {
+			continue
+		}
+		y, m, d := c.Date.Date()
+		loaded[time.Date(y, m, d, 0, 0, 0, 0, time.UTC)] = true
+		report.LoadedDays++
+		if !calendar.IsTradingDay(c.Date) {
+			report.UnexpectedDates = append(report.UnexpectedDates, c.Date)
+		}
+		if anomaly, ok := rowAnomaly(counts[max(0, i-opts.TrailingDays):i], c, opts); ok {
+			report.RowAnomalies = append(report.RowAnomalies, anomaly)
+		}
+	}
+	expected := calendar.TradingDays(from, to)
+	report.ExpectedDays = len(expected)
+	for _, d := range expected {
+		if !loaded[d] {
+			report.MissingDates = append(report.MissingDates, d)
+		}
+	}
+	return report
+}
+
+// rowAnomaly compares day with the average of the sessions before it.
+func rowAnomaly(trailing []DayCount, day DayCount, opts Options) (RowAnomaly, bool) {
+	if len(trailing) < min(minTrailingDays, opts.TrailingDays) {
+		return RowAnomaly{}, false
+	}
+	sum := 0.0
+	for _, c := range trailing {
+		sum += float64(c.Rows)
+	}
+	avg := sum / float64(len(trailing))
+	if avg == 0 {
+		return RowAnomaly{}, false
+	}
+	deviation := float64(day.Rows)/avg - 1
+	if math.Abs(deviation) <= opts.RowDeviation {
+		return RowAnomaly{}, false
+	}
+	return RowAnomaly{Date: day.Date, Rows: day.Rows, TrailingAverage: avg, Deviation: deviation}, true
+}
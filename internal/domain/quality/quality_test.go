@@ -0,0 +1,98 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var opts = Options{TrailingDays: 5, RowDeviation: 0.5, PriceJump: 0.3}
+
+func day(m time.Month, d int) time.Time {
+	return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCheckGivenMissingAndHalfLoadedDaysWhenCalledThenFlagsThem(t *testing.T) {
+	// Arrange
+	counts := []DayCount{
+		{Date: day(time.July, 14), Rows: 1000},
+		{Date: day(time.July, 15), Rows: 1100},
+		{Date: day(time.July, 16), Rows: 900},
+		{Date: day(time.July, 17), Rows: 1000},
+		{Date: day(time.July, 18), Rows: 1000},
+		{Date: day(time.July, 21), Rows: 1050},
+		{Date: day(time.July, 22), Rows: 400},
+		{Date: day(time.July, 24), Rows: 980},
+	}
+
+	// Act
+	report := Check(day(time.July, 21), day(time.July, 25), opts, counts, nil, nil)
+
+	// Assert
+	assert.Equal(t, 5, report.ExpectedDays)
+	assert.Equal(t, 3, report.LoadedDays)
+	assert.Equal(t, []time.Time{day(time.July, 23), day(time.July, 25)}, report.MissingDates)
+	assert.Len(t, report.RowAnomalies, 1)
+	assert.Equal(t, day(time.July, 22), report.RowAnomalies[0].Date)
+	assert.InDelta(t, 1010.0, report.RowAnomalies[0].TrailingAverage, 1e-9)
+	assert.InDelta(t, 400.0/1010-1, report.RowAnomalies[0].Deviation, 1e-9)
+	assert.NotNil(t, report.PriceJumps)
+	assert.False(t, report.Clean())
+}
+
+func TestCheckGivenDayOnHolidayWhenCalledThenFlagsUnexpectedDate(t *testing.T) {
+	// Arrange
+	counts := []DayCount{{Date: day(time.April, 17), Rows: 10}, {Date: day(time.April, 18), Rows: 10}}
+
+	// Act
+	report := Check(day(time.April, 17), day(time.April, 17), opts, counts, nil, nil)
+	holiday := Check(day(time.April, 17), day(time.April, 18), opts, counts, nil, nil)
+
+	// Assert
+	assert.True(t, report.Clean())
+	assert.Equal(t, []time.Time{day(time.April, 18)}, holiday.UnexpectedDates)
+}
+
+func TestCheckGivenShortHistoryWhenCalledThenSkipsRowComparison(t *testing.T) {
+	// Arrange
+	counts := []DayCount{{Date: day(time.July, 28), Rows: 1000}, {Date: day(time.July, 29), Rows: 10}}
+
+	// Act
+	report := Check(day(time.July, 28), day(time.July, 29), opts, counts, nil, nil)
+
+	// Assert
+	assert.Empty(t, report.RowAnomalies)
+	assert.True(t, report.Clean())
+}
+
+func TestCheckGivenJumpsAndDuplicatesWhenCalledThenReportsThem(t *testing.T) {
+	// Arrange
+	counts := []DayCount{{Date: day(time.July, 29), Rows: 10}}
+	jumps := []PriceJump{{Ticker: "PETR4", Date: day(time.July, 29), PreviousClose: 32, Close: 16, Change: -0.5}}
+	duplicates := []DuplicateTrade{{Ticker: "PETR4", Date: day(time.July, 29), TradeID: 10, Count: 2}}
+
+	// Act
+	report := Check(day(time.July, 29), day(time.July, 29), opts, counts, jumps, duplicates)
+
+	// Assert
+	assert.Equal(t, jumps, report.PriceJumps)
+	assert.Equal(t, duplicates, report.DuplicateTrades)
+	assert.False(t, report.Clean())
+}
+
+func TestLookbackStartGivenTrailingDaysWhenCalledThenStepsBackTradingDays(t *testing.T) {
+	// Act
+	start := LookbackStart(day(time.July, 28), opts)
+
+	// Assert
+	assert.Equal(t, day(time.July, 21), start)
+}
+
+func TestValidateGivenInvalidOptionsWhenCalledThenReturnsError(t *testing.T) {
+	// Assert
+	assert.NoError(t, opts.Validate())
+	assert.Error(t, Options{TrailingDays: 0, RowDeviation: 0.5, PriceJump: 0.3}.Validate())
+	assert.Error(t, Options{TrailingDays: 5, RowDeviation: 0, PriceJump: 0.3}.Validate())
+	assert.Error(t, Options{TrailingDays: 5, RowDeviation: 0.5, PriceJump: -1}.Validate())
+}
@@ -0,0 +1,143 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	return db
+}
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"sql/0001_create_foo.up.sql":   {Data: []byte("CREATE TABLE foo (id integer);")},
+		"sql/0001_create_foo.down.sql": {Data: []byte("DROP TABLE foo;")},
+		"sql/0002_create_bar.up.sql":   {Data: []byte("CREATE TABLE bar (id integer); CREATE INDEX idx_bar ON bar (id);")},
+		"sql/0002_create_bar.down.sql": {Data: []byte("DROP TABLE bar;")},
+	}
+}
+
+func TestNewMigratorGivenEmbeddedSchemaWhenLoadedThenMigrationsAreOrderedAndComplete(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+
+	// Act
+	m, err := NewMigrator(db)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, m.migrations)
+	for i, mig := range m.migrations {
+		assert.Equal(t, i+1, mig.Version)
+		assert.NotEmpty(t, mig.Up)
+		assert.NotEmpty(t, mig.Down)
+	}
+}
+
+func TestNewMigratorFromFSGivenMissingDownStepWhenLoadedThenReturnsError(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{"sql/0001_create_foo.up.sql": {Data: []byte("CREATE TABLE foo (id integer);")}}
+
+	// Act
+	_, err := NewMigratorFromFS(setupTestDB(t), fsys, "sql")
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestUpGivenPendingMigrationsWhenCalledThenAppliesThemOnce(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	m, err := NewMigratorFromFS(db, testFS(), "sql")
+	assert.NoError(t, err)
+
+	// Act
+	first, errFirst := m.Up(context.Background())
+	second, errSecond := m.Up(context.Background())
+
+	// Assert
+	assert.NoError(t, errFirst)
+	assert.Len(t, first, 2)
+	assert.NoError(t, errSecond)
+	assert.Empty(t, second)
+	assert.True(t, db.Migrator().HasTable("foo"))
+	assert.True(t, db.Migrator().HasTable("bar"))
+}
+
+func TestDownGivenAppliedMigrationsWhenCalledThenRevertsLatestOnly(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	m, _ := NewMigratorFromFS(db, testFS(), "sql")
+	_, _ = m.Up(context.Background())
+
+	// Act
+	reverted, err := m.Down(context.Background())
+	statuses, errStatus := m.Status(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reverted.Version)
+	assert.False(t, db.Migrator().HasTable("bar"))
+	assert.True(t, db.Migrator().HasTable("foo"))
+	assert.NoError(t, errStatus)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestUpGivenFailingMigrationWhenCalledThenStopsAndDoesNotRecordIt(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	fsys := testFS()
+	fsys["sql/0002_create_bar.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE bar (id integer")}
+	m, _ := NewMigratorFromFS(db, fsys, "sql")
+
+	// Act
+	applied, err := m.Up(context.Background())
+	statuses, _ := m.Status(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Len(t, applied, 1)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestPendingGivenFreshDatabaseWhenCalledThenListsEveryMigrationWithoutCreatingTable(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	m, _ := NewMigratorFromFS(db, testFS(), "sql")
+
+	// Act
+	pending, err := m.Pending(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.False(t, db.Migrator().HasTable("schema_migrations"))
+}
+
+func TestPendingGivenPartiallyAppliedSchemaWhenCalledThenListsOnlyMissingMigrations(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	fsys := testFS()
+	fsys["sql/0002_create_bar.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE bar (id integer")}
+	m, _ := NewMigratorFromFS(db, fsys, "sql")
+	_, _ = m.Up(context.Background())
+
+	// Act
+	pending, err := m.Pending(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, 2, pending[0].Version)
+	}
+}
@@ -0,0 +1,51 @@
+-- tradings holds every trade loaded from the B3 files, range partitioned on data_negocio.
+-- Partitions are created by the ingestion service as new dates arrive.
+--
+-- Installs created before versioned migrations have a plain tradings table built by
+-- GORM AutoMigrate. It is renamed here and its rows are moved into monthly partitions below.
+-- The ingestion service reuses these partitions for their dates whatever PARTITION_GRANULARITY is.
+DO $$ BEGIN
+ IF EXISTS (SELECT 1 FROM pg_class WHERE oid = to_regclass('tradings') AND relkind = 'r') THEN
+  ALTER TABLE tradings RENAME TO tradings_legacy;
+  ALTER TABLE tradings_legacy DROP CONSTRAINT IF EXISTS unique_trade_constraint;
+  DROP INDEX IF EXISTS idx_tradings_ticker_data;
+ END IF;
+END$$;
+
+CREATE TABLE IF NOT EXISTS tradings (
+    data_negocio date NOT NULL,
+    codigo_instrumento text,
+    preco_negocio numeric,
+    quantidade_negociada bigint,
+    hora_fechamento bigint,
+    hash_arquivo text,
+    codigo_identificador_negocio bigint
+) PARTITION BY RANGE (data_negocio);
+
+-- codigo_identificador_negocio is part of the key so distinct trades at the same time are kept.
+DO $$ BEGIN
+ IF NOT EXISTS (
+ SELECT 1 FROM pg_constraint WHERE conname = 'unique_trade_constraint'
+ AND conrelid = 'tradings'::regclass) THEN
+ ALTER TABLE tradings ADD CONSTRAINT unique_trade_constraint UNIQUE (
+ data_negocio, codigo_instrumento, hora_fechamento, codigo_identificador_negocio);
+ END IF;
+END$$;
+
+CREATE INDEX IF NOT EXISTS idx_tradings_ticker_data ON tradings (codigo_instrumento, data_negocio);
+
+DO $$
+DECLARE
+ m date;
+BEGIN
+ IF to_regclass('tradings_legacy') IS NOT NULL THEN
+  FOR m IN SELECT DISTINCT date_trunc('month', data_negocio)::date FROM tradings_legacy WHERE data_negocio IS NOT NULL LOOP
+   EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF tradings FOR VALUES FROM (%L) TO (%L)',
+    'tradings_p' || to_char(m, 'YYYY_MM'), m, (m + interval '1 month')::date);
+  END LOOP;
+  INSERT INTO tradings (data_negocio, codigo_instrumento, preco_negocio, quantidade_negociada, hora_fechamento, hash_arquivo, codigo_identificador_negocio)
+  SELECT data_negocio, codigo_instrumento, preco_negocio, quantidade_negociada, hora_fechamento, hash_arquivo, codigo_identificador_negocio
+  FROM tradings_legacy WHERE data_negocio IS NOT NULL;
+  DROP TABLE tradings_legacy;
+ END IF;
+END$$;
@@ -0,0 +1,10 @@
+-- tradings_unlogged is the staging area filled by COPY. It is UNLOGGED because its rows are
+-- short lived: they are moved into tradings partitions and truncated at the end of each run.
+CREATE UNLOGGED TABLE IF NOT EXISTS tradings_unlogged (
+    data_negocio date,
+    codigo_instrumento text,
+    preco_negocio numeric,
+    quantidade_negociada bigint,
+    hora_fechamento bigint,
+    codigo_identificador_negocio bigint
+);
@@ -0,0 +1,16 @@
+-- daily_bars keeps one OHLCV row per instrument and trading day. It survives the retention
+-- purge of tradings, so daily history stays available after the raw trades are gone.
+CREATE TABLE IF NOT EXISTS daily_bars (
+    codigo_instrumento text NOT NULL,
+    data_negocio date NOT NULL,
+    preco_abertura numeric NOT NULL,
+    preco_maximo numeric NOT NULL,
+    preco_minimo numeric NOT NULL,
+    preco_fechamento numeric NOT NULL,
+    volume bigint NOT NULL,
+    numero_negocios bigint NOT NULL,
+    vwap numeric NOT NULL,
+    PRIMARY KEY (codigo_instrumento, data_negocio)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_bars_data ON daily_bars (data_negocio);
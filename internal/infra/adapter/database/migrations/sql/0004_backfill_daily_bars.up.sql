@@ -0,0 +1,16 @@
+-- Builds daily_bars for the trades loaded before the ingestion started maintaining them.
+INSERT INTO daily_bars (codigo_instrumento, data_negocio, preco_abertura, preco_maximo, preco_minimo,
+    preco_fechamento, volume, numero_negocios, vwap)
+SELECT
+    codigo_instrumento,
+    data_negocio,
+    (array_agg(preco_negocio ORDER BY hora_fechamento, codigo_identificador_negocio))[1],
+    MAX(preco_negocio),
+    MIN(preco_negocio),
+    (array_agg(preco_negocio ORDER BY hora_fechamento DESC, codigo_identificador_negocio DESC))[1],
+    SUM(quantidade_negociada),
+    COUNT(*),
+    COALESCE(SUM(preco_negocio * quantidade_negociada) / NULLIF(SUM(quantidade_negociada), 0), AVG(preco_negocio))
+FROM tradings
+GROUP BY codigo_instrumento, data_negocio
+ON CONFLICT (codigo_instrumento, data_negocio) DO NOTHING;
@@ -0,0 +1,17 @@
+-- instruments is the catalog of every traded codigo_instrumento. It is kept up to date from
+-- daily_bars at the end of each ingestion, so listing tickers never scans tradings.
+CREATE TABLE IF NOT EXISTS instruments (
+    codigo_instrumento text PRIMARY KEY,
+    first_trade_date date NOT NULL,
+    last_trade_date date NOT NULL,
+    total_trades bigint NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_instruments_last_trade_date ON instruments (last_trade_date);
+
+INSERT INTO instruments (codigo_instrumento, first_trade_date, last_trade_date, total_trades)
+SELECT codigo_instrumento, MIN(data_negocio), MAX(data_negocio), SUM(numero_negocios)
+FROM daily_bars
+GROUP BY codigo_instrumento
+ON CONFLICT (codigo_instrumento) DO NOTHING;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_tradings_ticker_data ON tradings (codigo_instrumento, data_negocio);
+
+DROP INDEX IF EXISTS idx_tradings_ticker_trade_order;
@@ -0,0 +1,7 @@
+-- Matches the (data_negocio, hora_fechamento, codigo_identificador_negocio) order used to page
+-- through the trades of a ticker. It starts with the columns of idx_tradings_ticker_data, which
+-- becomes redundant.
+CREATE INDEX IF NOT EXISTS idx_tradings_ticker_trade_order
+    ON tradings (codigo_instrumento, data_negocio, hora_fechamento, codigo_identificador_negocio);
+
+DROP INDEX IF EXISTS idx_tradings_ticker_data;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_instruments_root;
+DROP INDEX IF EXISTS idx_instruments_asset_class_root;
+
+ALTER TABLE instruments
+    DROP COLUMN IF EXISTS contract_year,
+    DROP COLUMN IF EXISTS contract_month,
+    DROP COLUMN IF EXISTS strike_code,
+    DROP COLUMN IF EXISTS option_series,
+    DROP COLUMN IF EXISTS option_type,
+    DROP COLUMN IF EXISTS root,
+    DROP COLUMN IF EXISTS asset_class;
@@ -0,0 +1,13 @@
+-- The classification columns are derived from codigo_instrumento by the ingestion, which fills
+-- every row whose asset_class is still null, so existing instruments are classified on the next run.
+ALTER TABLE instruments
+    ADD COLUMN IF NOT EXISTS asset_class text,
+    ADD COLUMN IF NOT EXISTS root text,
+    ADD COLUMN IF NOT EXISTS option_type text,
+    ADD COLUMN IF NOT EXISTS option_series text,
+    ADD COLUMN IF NOT EXISTS strike_code text,
+    ADD COLUMN IF NOT EXISTS contract_month smallint,
+    ADD COLUMN IF NOT EXISTS contract_year smallint;
+
+CREATE INDEX IF NOT EXISTS idx_instruments_asset_class_root ON instruments (asset_class, root);
+CREATE INDEX IF NOT EXISTS idx_instruments_root ON instruments (root);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS block_trades;
+DROP TABLE IF EXISTS trade_size_medians;
@@ -0,0 +1,25 @@
+-- trade_size_medians keeps the median trade size of each instrument and day. The rolling median
+-- used to flag block trades is the median of these over the last sessions, so detection never
+-- rescans more than the loaded days of tradings.
+CREATE TABLE IF NOT EXISTS trade_size_medians (
+    codigo_instrumento text NOT NULL,
+    data_negocio date NOT NULL,
+    median_quantity double precision NOT NULL,
+    PRIMARY KEY (codigo_instrumento, data_negocio)
+);
+
+-- block_trades holds the trades flagged as unusually large at the end of each ingestion. Rows are
+-- copies of tradings, so reading them never scans the raw trades.
+CREATE TABLE IF NOT EXISTS block_trades (
+    codigo_instrumento text NOT NULL,
+    data_negocio date NOT NULL,
+    hora_fechamento bigint NOT NULL,
+    codigo_identificador_negocio bigint NOT NULL,
+    preco_negocio numeric NOT NULL,
+    quantidade_negociada bigint NOT NULL,
+    median_quantity double precision NOT NULL,
+    reason text NOT NULL,
+    PRIMARY KEY (codigo_instrumento, data_negocio, hora_fechamento, codigo_identificador_negocio)
+);
+
+CREATE INDEX IF NOT EXISTS idx_block_trades_data ON block_trades (data_negocio);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS brokers;
+
+ALTER TABLE tradings_unlogged
+    DROP COLUMN IF EXISTS codigo_participante_vendedor,
+    DROP COLUMN IF EXISTS codigo_participante_comprador;
+
+ALTER TABLE tradings
+    DROP COLUMN IF EXISTS codigo_participante_vendedor,
+    DROP COLUMN IF EXISTS codigo_participante_comprador;
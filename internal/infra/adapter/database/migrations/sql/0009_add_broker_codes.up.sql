@@ -0,0 +1,15 @@
+-- The buyer and seller participant codes of each trade. Trades loaded before this migration keep
+-- them null and are left out of the broker flow until their day is loaded again.
+ALTER TABLE tradings
+    ADD COLUMN IF NOT EXISTS codigo_participante_comprador integer,
+    ADD COLUMN IF NOT EXISTS codigo_participante_vendedor integer;
+
+ALTER TABLE tradings_unlogged
+    ADD COLUMN IF NOT EXISTS codigo_participante_comprador integer,
+    ADD COLUMN IF NOT EXISTS codigo_participante_vendedor integer;
+
+-- brokers maps participant codes to broker names. It is filled from a file with -brokers.
+CREATE TABLE IF NOT EXISTS brokers (
+    codigo_participante integer PRIMARY KEY,
+    nome text NOT NULL
+);
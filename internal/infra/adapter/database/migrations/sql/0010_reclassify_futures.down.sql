@@ -0,0 +1,2 @@
+-- The reclassification only corrects derived data, there is nothing to undo.
+SELECT 1;
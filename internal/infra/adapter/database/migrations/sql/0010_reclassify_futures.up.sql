@@ -0,0 +1,5 @@
+-- Codes of known futures roots ending in 32 to 39, such as DI1F33, were classified as BDRs.
+-- Clearing them lets the next ingestion classify them again as futures.
+UPDATE instruments SET asset_class = NULL
+WHERE asset_class = 'bdr'
+  AND codigo_instrumento ~ '^(WDO|DOL|DI1|WIN|IND|BGI|CCM)[FGHJKMNQUVXZ]\d{2}$';
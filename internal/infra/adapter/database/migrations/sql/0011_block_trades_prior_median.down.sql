@@ -0,0 +1,2 @@
+UPDATE block_trades SET median_quantity = 0 WHERE median_quantity IS NULL;
+ALTER TABLE block_trades ALTER COLUMN median_quantity SET NOT NULL;
@@ -0,0 +1,5 @@
+-- The rolling median of a block trade is now taken over the sessions before the trade's own, so
+-- the first session of a ticker has none. Trades flagged by the threshold on that session keep a
+-- null median. Rows flagged earlier keep the median they were flagged with until their day is
+-- loaded again.
+ALTER TABLE block_trades ALTER COLUMN median_quantity DROP NOT NULL;
@@ -0,0 +1,27 @@
+package partition
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var boundRe = regexp.MustCompile(`FROM \('(\d{4}-\d{2}-\d{2})'\) TO \('(\d{4}-\d{2}-\d{2})'\)`)
+
+// ParseBound reads the range [from, to) of a tradings partition from its
+// pg_get_expr(relpartbound) text. Default and non date bounds return an error.
+func ParseBound(bound string) (time.Time, time.Time, error) {
+	match := boundRe.FindStringSubmatch(bound)
+	if match == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported partition bound %q", bound)
+	}
+	from, err := time.Parse("2006-01-02", match[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := time.Parse("2006-01-02", match[2])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
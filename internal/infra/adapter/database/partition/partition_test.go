@@ -0,0 +1,26 @@
+package partition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBoundGivenRangeExpressionWhenParsedThenReturnsDates(t *testing.T) {
+	// Act
+	from, to, err := ParseBound("FOR VALUES FROM ('2025-07-01') TO ('2025-08-01')")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), to)
+}
+
+func TestParseBoundGivenDefaultPartitionWhenParsedThenReturnsError(t *testing.T) {
+	// Act
+	_, _, err := ParseBound("DEFAULT")
+
+	// Assert
+	assert.Error(t, err)
+}
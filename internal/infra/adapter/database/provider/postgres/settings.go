@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"b3-ingest/internal/infra/adapter/database"
-	"b3-ingest/internal/infra/adapter/database/orm/models"
 	"fmt"
 
 	"gorm.io/gorm/logger"
@@ -55,7 +54,6 @@ func NewPostgres(config database.Config) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	db.AutoMigrate(&models.Trading{})
 	return db, nil
 }
 
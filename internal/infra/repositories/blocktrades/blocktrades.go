@@ -0,0 +1,177 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mediansSQL stores the median trade size of every instrument traded on the given days.
+const mediansSQL = `
+	INSERT INTO trade_size_medians (codigo_instrumento, data_negocio, median_quantity)
+	SELECT codigo_instrumento, data_negocio, PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY quantidade_negociada)
+	FROM tradings
+	WHERE data_negocio IN ?
+	GROUP BY codigo_instrumento, data_negocio
+`
+
+// detectSQL flags the trades of the given days against the rolling median of their ticker, the
+// median of the daily medians of the last @window sessions before the trade's day. The trade's own
+// session is left out so a large print never raises the median it is compared with. On the first
+// session of a ticker the median is null and only the threshold test applies.
+const detectSQL = `
+	INSERT INTO block_trades (codigo_instrumento, data_negocio, hora_fechamento, codigo_identificador_negocio,
+		preco_negocio, quantidade_negociada, median_quantity, reason)
+	WITH rolling AS (
+		SELECT d.codigo_instrumento, d.data_negocio,
+			(SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY w.median_quantity)
+			 FROM (
+				SELECT m.median_quantity
+				FROM trade_size_medians m
+				WHERE m.codigo_instrumento = d.codigo_instrumento AND m.data_negocio < d.data_negocio
+				ORDER BY m.data_negocio DESC
+				LIMIT @window
+			 ) w) AS median_quantity
+		FROM trade_size_medians d
+		WHERE d.data_negocio IN @dates
+	)
+	SELECT t.codigo_instrumento, t.data_negocio, t.hora_fechamento, t.codigo_identificador_negocio,
+		t.preco_negocio, t.quantidade_negociada, r.median_quantity,
+		CASE WHEN CAST(@min_quantity AS bigint) > 0 AND t.quantidade_negociada >= @min_quantity
+			THEN 'threshold' ELSE 'median' END
+	FROM tradings t
+	JOIN rolling r ON r.codigo_instrumento = t.codigo_instrumento AND r.data_negocio = t.data_negocio
+	WHERE t.data_negocio IN @dates
+		AND ((CAST(@min_quantity AS bigint) > 0 AND t.quantidade_negociada >= @min_quantity)
+			OR (CAST(@multiple AS double precision) > 0 AND t.quantidade_negociada > CAST(@multiple AS double precision) * r.median_quantity))
+	ON CONFLICT DO NOTHING
+`
+
+// blockTradeRow is a row of the block_trades table.
+type blockTradeRow struct {
+	CodigoInstrumento          string
+	DataNegocio                time.Time
+	HoraFechamento             int64
+	CodigoIdentificadorNegocio int64
+	PrecoNegocio               float64
+	QuantidadeNegociada        int64
+	MedianQuantity             *float64
+	Reason                     string
+}
+
+type BlockTradeRepository interface {
+	// DetectDates rebuilds the daily medians and the block trades of the given days from the trades
+	// currently stored. Days after them that were detected earlier keep their flags.
+	DetectDates(ctx context.Context, db *gorm.DB, dates []time.Time, rule models.BlockTradeRule) (int64, error)
+	// List returns a page of block trades in trade order, together with the number of matches.
+	List(ctx context.Context, db *gorm.DB, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error)
+	// CountBefore counts the block trades and the daily medians stored for days before cutoff.
+	CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (trades int64, medians int64, err error)
+	// DeleteBefore deletes the block trades and the daily medians of the days before cutoff.
+	DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (trades int64, medians int64, err error)
+}
+
+type blockTradeRepository struct{}
+
+func NewBlockTradeRepository() BlockTradeRepository {
+	return &blockTradeRepository{}
+}
+
+func (r *blockTradeRepository) DetectDates(ctx context.Context, db *gorm.DB, dates []time.Time, rule models.BlockTradeRule) (int64, error) {
+	if len(dates) == 0 || !rule.Enabled() {
+		return 0, nil
+	}
+	var rows int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM trade_size_medians WHERE data_negocio IN ?`, dates).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(mediansSQL, dates).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM block_trades WHERE data_negocio IN ?`, dates).Error; err != nil {
+			return err
+		}
+		res := tx.Exec(detectSQL, map[string]interface{}{
+			"dates": dates,
+			// A threshold-only rule may leave the window unset; the median is still stored.
+			"window":       max(rule.Window, 1),
+			"multiple":     rule.Multiple,
+			"min_quantity": rule.MinQuantity,
+		})
+		rows = res.RowsAffected
+		return res.Error
+	})
+	return rows, err
+}
+
+func (r *blockTradeRepository) List(ctx context.Context, db *gorm.DB, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error) {
+	query := db.WithContext(ctx).Table("block_trades")
+	if filter.Ticker != "" {
+		query = query.Where("codigo_instrumento = ?", filter.Ticker)
+	}
+	if filter.Prefix != "" {
+		query = query.Where("codigo_instrumento LIKE ?", filter.Prefix+"%")
+	}
+	if filter.From != nil {
+		query = query.Where("data_negocio >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("data_negocio <= ?", *filter.To)
+	}
+	if filter.MinQuantity > 0 {
+		query = query.Where("quantidade_negociada >= ?", filter.MinQuantity)
+	}
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	// The filtered query is shared by the count and the page.
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []blockTradeRow
+	err := query.Order("data_negocio, hora_fechamento, codigo_identificador_negocio, codigo_instrumento").
+		Limit(filter.Limit).Offset(filter.Offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	trades := make([]models.BlockTrade, 0, len(rows))
+	for _, row := range rows {
+		trades = append(trades, models.BlockTrade{
+			Ticker: row.CodigoInstrumento,
+			Trade: models.Trade{
+				Date:        row.DataNegocio,
+				ClosingTime: row.HoraFechamento,
+				Price:       row.PrecoNegocio,
+				Quantity:    row.QuantidadeNegociada,
+				TradeID:     row.CodigoIdentificadorNegocio,
+			},
+			MedianQuantity: row.MedianQuantity,
+			Reason:         row.Reason,
+		})
+	}
+	return trades, total, nil
+}
+
+func (r *blockTradeRepository) CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, int64, error) {
+	var trades, medians int64
+	if err := db.WithContext(ctx).Table("block_trades").Where("data_negocio < ?", cutoff).Count(&trades).Error; err != nil {
+		return 0, 0, err
+	}
+	err := db.WithContext(ctx).Table("trade_size_medians").Where("data_negocio < ?", cutoff).Count(&medians).Error
+	return trades, medians, err
+}
+
+func (r *blockTradeRepository) DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, int64, error) {
+	trades := db.WithContext(ctx).Exec(`DELETE FROM block_trades WHERE data_negocio < ?`, cutoff)
+	if trades.Error != nil {
+		return 0, 0, trades.Error
+	}
+	medians := db.WithContext(ctx).Exec(`DELETE FROM trade_size_medians WHERE data_negocio < ?`, cutoff)
+	return trades.RowsAffected, medians.RowsAffected, medians.Error
+}
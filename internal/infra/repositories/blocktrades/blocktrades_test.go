@@ -0,0 +1,163 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type BlockTrade struct {
+	CodigoInstrumento          string    `gorm:"primaryKey"`
+	DataNegocio                time.Time `gorm:"primaryKey"`
+	HoraFechamento             int64     `gorm:"primaryKey"`
+	CodigoIdentificadorNegocio int64     `gorm:"primaryKey"`
+	PrecoNegocio               float64
+	QuantidadeNegociada        int64
+	MedianQuantity             *float64
+	Reason                     string
+}
+
+type TradeSizeMedian struct {
+	CodigoInstrumento string    `gorm:"primaryKey"`
+	DataNegocio       time.Time `gorm:"primaryKey"`
+	MedianQuantity    float64
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	db.AutoMigrate(&BlockTrade{}, &TradeSizeMedian{})
+	return db
+}
+
+func median(q float64) *float64 {
+	return &q
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGivenNoDatesWhenDetectDatesThenDoesNothing(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewBlockTradeRepository()
+
+	// Act
+	rows, err := repo.DetectDates(context.Background(), db, nil, models.BlockTradeRule{Multiple: 20, Window: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestGivenDisabledRuleWhenDetectDatesThenDoesNothing(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewBlockTradeRepository()
+
+	// Act
+	rows, err := repo.DetectDates(context.Background(), db, []time.Time{day(29)}, models.BlockTradeRule{Window: 20})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestGivenMissingTablesWhenDetectDatesThenReturnsError(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	repo := NewBlockTradeRepository()
+
+	// Act
+	_, err = repo.DetectDates(context.Background(), db, []time.Time{day(29)}, models.BlockTradeRule{Multiple: 20, Window: 20})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestGivenBlockTradesWhenListThenFiltersAndPaginates(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]BlockTrade{
+		{CodigoInstrumento: "PETR4", DataNegocio: day(28), HoraFechamento: 100000000, CodigoIdentificadorNegocio: 1, PrecoNegocio: 32.1, QuantidadeNegociada: 500000, MedianQuantity: median(100), Reason: models.BlockReasonThreshold},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(29), HoraFechamento: 110000000, CodigoIdentificadorNegocio: 2, PrecoNegocio: 32.4, QuantidadeNegociada: 4000, MedianQuantity: median(100), Reason: models.BlockReasonMedian},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(29), HoraFechamento: 120000000, CodigoIdentificadorNegocio: 3, PrecoNegocio: 32.5, QuantidadeNegociada: 3000, MedianQuantity: median(100), Reason: models.BlockReasonMedian},
+		{CodigoInstrumento: "VALE3", DataNegocio: day(29), HoraFechamento: 110000000, CodigoIdentificadorNegocio: 4, PrecoNegocio: 55.0, QuantidadeNegociada: 9000, MedianQuantity: median(200), Reason: models.BlockReasonMedian},
+	})
+	repo := NewBlockTradeRepository()
+	from := day(29)
+
+	// Act
+	page, total, err := repo.List(context.Background(), db, models.BlockTradeFilter{
+		Prefix: "PETR", From: &from, Reason: models.BlockReasonMedian, Limit: 1, Offset: 1,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "PETR4", page[0].Ticker)
+	assert.Equal(t, int64(3), page[0].TradeID)
+	assert.Equal(t, int64(3000), page[0].Quantity)
+	if assert.NotNil(t, page[0].MedianQuantity) {
+		assert.Equal(t, 100.0, *page[0].MedianQuantity)
+	}
+	assert.True(t, day(29).Equal(page[0].Date))
+}
+
+func TestGivenMinQuantityWhenListThenReturnsOnlyLargerTrades(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]BlockTrade{
+		{CodigoInstrumento: "PETR4", DataNegocio: day(29), CodigoIdentificadorNegocio: 1, QuantidadeNegociada: 4000, Reason: models.BlockReasonMedian},
+		{CodigoInstrumento: "VALE3", DataNegocio: day(29), CodigoIdentificadorNegocio: 2, QuantidadeNegociada: 9000, Reason: models.BlockReasonMedian},
+	})
+	repo := NewBlockTradeRepository()
+
+	// Act
+	page, total, err := repo.List(context.Background(), db, models.BlockTradeFilter{MinQuantity: 5000, Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "VALE3", page[0].Ticker)
+}
+
+func TestGivenRowsAroundCutoffWhenCountAndDeleteBeforeThenOnlyTouchesOlderDays(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]BlockTrade{
+		{CodigoInstrumento: "PETR4", DataNegocio: day(28), CodigoIdentificadorNegocio: 1, QuantidadeNegociada: 4000, Reason: models.BlockReasonThreshold},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(29), CodigoIdentificadorNegocio: 2, QuantidadeNegociada: 5000, MedianQuantity: median(100), Reason: models.BlockReasonMedian},
+	})
+	db.Create(&[]TradeSizeMedian{
+		{CodigoInstrumento: "PETR4", DataNegocio: day(25), MedianQuantity: 100},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(28), MedianQuantity: 100},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(29), MedianQuantity: 100},
+	})
+	repo := NewBlockTradeRepository()
+
+	// Act
+	countedTrades, countedMedians, errCount := repo.CountBefore(context.Background(), db, day(29))
+	deletedTrades, deletedMedians, errDelete := repo.DeleteBefore(context.Background(), db, day(29))
+
+	// Assert
+	assert.NoError(t, errCount)
+	assert.Equal(t, int64(1), countedTrades)
+	assert.Equal(t, int64(2), countedMedians)
+	assert.NoError(t, errDelete)
+	assert.Equal(t, int64(1), deletedTrades)
+	assert.Equal(t, int64(2), deletedMedians)
+	var remaining int64
+	db.Model(&TradeSizeMedian{}).Count(&remaining)
+	assert.Equal(t, int64(1), remaining)
+	_, total, _ := repo.List(context.Background(), db, models.BlockTradeFilter{Limit: 10})
+	assert.Equal(t, int64(1), total)
+}
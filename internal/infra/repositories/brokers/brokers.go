@@ -0,0 +1,82 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const saveBrokerSQL = `
+	INSERT INTO brokers (codigo_participante, nome) VALUES (?, ?)
+	ON CONFLICT (codigo_participante) DO UPDATE SET nome = excluded.nome
+`
+
+// flowsSQL splits every trade into a buy for the buyer and a sale for the seller and sums them per
+// participant. Trades without participant codes are left out.
+const flowsSQL = `
+	WITH sides AS (
+		SELECT codigo_participante_comprador AS code,
+			quantidade_negociada AS buy_quantity, 0 AS sell_quantity,
+			preco_negocio * quantidade_negociada AS buy_notional, 0 AS sell_notional,
+			1 AS buy_trades, 0 AS sell_trades
+		FROM tradings
+		WHERE codigo_instrumento = @ticker AND data_negocio >= @start AND data_negocio <= @end
+			AND codigo_participante_comprador IS NOT NULL
+		UNION ALL
+		SELECT codigo_participante_vendedor,
+			0, quantidade_negociada,
+			0, preco_negocio * quantidade_negociada,
+			0, 1
+		FROM tradings
+		WHERE codigo_instrumento = @ticker AND data_negocio >= @start AND data_negocio <= @end
+			AND codigo_participante_vendedor IS NOT NULL
+	)
+	SELECT s.code, COALESCE(b.nome, '') AS name,
+		SUM(s.buy_quantity) AS buy_quantity, SUM(s.sell_quantity) AS sell_quantity,
+		SUM(s.buy_notional) AS buy_notional, SUM(s.sell_notional) AS sell_notional,
+		SUM(s.buy_trades) AS buy_trades, SUM(s.sell_trades) AS sell_trades
+	FROM sides s
+	LEFT JOIN brokers b ON b.codigo_participante = s.code
+	GROUP BY s.code, b.nome
+	ORDER BY s.code
+`
+
+type BrokerRepository interface {
+	// SaveBrokers inserts the given brokers, replacing the name of codes already mapped.
+	SaveBrokers(ctx context.Context, db *gorm.DB, brokers []models.Broker) error
+	// GetFlows returns what each participant bought and sold of ticker between startDate and
+	// endDate, ordered by participant code.
+	GetFlows(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.BrokerFlow, error)
+}
+
+type brokerRepository struct{}
+
+func NewBrokerRepository() BrokerRepository {
+	return &brokerRepository{}
+}
+
+func (r *brokerRepository) SaveBrokers(ctx context.Context, db *gorm.DB, brokers []models.Broker) error {
+	if len(brokers) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, b := range brokers {
+			if err := tx.Exec(saveBrokerSQL, b.Code, b.Name).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *brokerRepository) GetFlows(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.BrokerFlow, error) {
+	var flows []models.BrokerFlow
+	err := db.WithContext(ctx).Raw(flowsSQL, map[string]interface{}{
+		"ticker": ticker,
+		"start":  startDate,
+		"end":    endDate,
+	}).Scan(&flows).Error
+	return flows, err
+}
@@ -0,0 +1,88 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type Trading struct {
+	DataNegocio                 time.Time
+	CodigoInstrumento           string
+	PrecoNegocio                float64
+	QuantidadeNegociada         int64
+	HoraFechamento              int64
+	CodigoIdentificadorNegocio  int64
+	CodigoParticipanteComprador *int
+	CodigoParticipanteVendedor  *int
+}
+
+type Broker struct {
+	CodigoParticipante int `gorm:"primaryKey;autoIncrement:false"`
+	Nome               string
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	db.AutoMigrate(&Trading{}, &Broker{})
+	return db
+}
+
+func code(c int) *int {
+	return &c
+}
+
+func TestGivenTradesWhenGetFlowsThenSumsBuysAndSalesPerParticipant(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	db.Create(&[]Trading{
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 32, QuantidadeNegociada: 100, CodigoIdentificadorNegocio: 1, CodigoParticipanteComprador: code(3), CodigoParticipanteVendedor: code(8)},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 33, QuantidadeNegociada: 200, CodigoIdentificadorNegocio: 2, CodigoParticipanteComprador: code(3), CodigoParticipanteVendedor: code(72)},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 31, QuantidadeNegociada: 50, CodigoIdentificadorNegocio: 3, CodigoParticipanteComprador: code(8), CodigoParticipanteVendedor: code(3)},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 31, QuantidadeNegociada: 70, CodigoIdentificadorNegocio: 4},
+		{DataNegocio: day, CodigoInstrumento: "VALE3", PrecoNegocio: 55, QuantidadeNegociada: 10, CodigoIdentificadorNegocio: 5, CodigoParticipanteComprador: code(3), CodigoParticipanteVendedor: code(8)},
+		{DataNegocio: day.AddDate(0, 0, 1), CodigoInstrumento: "PETR4", PrecoNegocio: 34, QuantidadeNegociada: 10, CodigoIdentificadorNegocio: 6, CodigoParticipanteComprador: code(3), CodigoParticipanteVendedor: code(8)},
+	})
+	db.Create(&Broker{CodigoParticipante: 3, Nome: "XP INVESTIMENTOS"})
+	repo := NewBrokerRepository()
+
+	// Act
+	flows, err := repo.GetFlows(context.Background(), db, "PETR4", day, day)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, flows, 3)
+	assert.Equal(t, models.BrokerFlow{
+		Code: 3, Name: "XP INVESTIMENTOS", BuyQuantity: 300, SellQuantity: 50,
+		BuyNotional: 9800, SellNotional: 1550, BuyTrades: 2, SellTrades: 1,
+	}, flows[0])
+	assert.Equal(t, 8, flows[1].Code)
+	assert.Equal(t, "", flows[1].Name)
+	assert.Equal(t, int64(50), flows[1].BuyQuantity)
+	assert.Equal(t, int64(100), flows[1].SellQuantity)
+	assert.Equal(t, 72, flows[2].Code)
+	assert.Equal(t, int64(200), flows[2].SellQuantity)
+}
+
+func TestGivenExistingBrokerWhenSaveBrokersThenReplacesName(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Broker{CodigoParticipante: 3, Nome: "XP"})
+	repo := NewBrokerRepository()
+
+	// Act
+	err := repo.SaveBrokers(context.Background(), db, []models.Broker{{Code: 3, Name: "XP INVESTIMENTOS"}, {Code: 8, Name: "UBS BB"}})
+
+	// Assert
+	assert.NoError(t, err)
+	var brokers []Broker
+	db.Order("codigo_participante").Find(&brokers)
+	assert.Equal(t, []Broker{{CodigoParticipante: 3, Nome: "XP INVESTIMENTOS"}, {CodigoParticipante: 8, Nome: "UBS BB"}}, brokers)
+}
@@ -0,0 +1,97 @@
+package dailybars
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// materializeSQL aggregates raw trades into one OHLCV row per instrument and day.
+// Open and close are the first and last trades of the day ordered by closing time and trade id.
+const materializeSQL = `
+	INSERT INTO daily_bars (codigo_instrumento, data_negocio, preco_abertura, preco_maximo, preco_minimo,
+		preco_fechamento, volume, numero_negocios, vwap)
+	SELECT
+		codigo_instrumento,
+		data_negocio,
+		(array_agg(preco_negocio ORDER BY hora_fechamento, codigo_identificador_negocio))[1],
+		MAX(preco_negocio),
+		MIN(preco_negocio),
+		(array_agg(preco_negocio ORDER BY hora_fechamento DESC, codigo_identificador_negocio DESC))[1],
+		SUM(quantidade_negociada),
+		COUNT(*),
+		COALESCE(SUM(preco_negocio * quantidade_negociada) / NULLIF(SUM(quantidade_negociada), 0), AVG(preco_negocio))
+	FROM tradings
+	WHERE %s
+	GROUP BY codigo_instrumento, data_negocio
+`
+
+type DailyBarRepository interface {
+	// MaterializeBefore stores bars for every day before cutoff that has no bar yet.
+	MaterializeBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error)
+	// MaterializeDates upserts the bars of the given days from the trades currently stored and
+	// removes the bars of those days whose ticker no longer has trades. Other days are untouched.
+	MaterializeDates(ctx context.Context, db *gorm.DB, dates []time.Time) (int64, error)
+	// CountBefore returns the number of bars of the days before cutoff.
+	CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error)
+	// DeleteBefore removes the bars of the days before cutoff and returns how many were removed.
+	DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error)
+}
+
+type dailyBarRepository struct{}
+
+func NewDailyBarRepository() DailyBarRepository {
+	return &dailyBarRepository{}
+}
+
+func (r *dailyBarRepository) MaterializeBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	query := fmt.Sprintf(materializeSQL, "data_negocio < ?") +
+		` ON CONFLICT (codigo_instrumento, data_negocio) DO NOTHING`
+	res := db.WithContext(ctx).Exec(query, cutoff)
+	return res.RowsAffected, res.Error
+}
+
+func (r *dailyBarRepository) MaterializeDates(ctx context.Context, db *gorm.DB, dates []time.Time) (int64, error) {
+	if len(dates) == 0 {
+		return 0, nil
+	}
+	var rows int64
+	query := fmt.Sprintf(materializeSQL, "data_negocio IN ?") + `
+		ON CONFLICT (codigo_instrumento, data_negocio) DO UPDATE SET
+			preco_abertura = EXCLUDED.preco_abertura,
+			preco_maximo = EXCLUDED.preco_maximo,
+			preco_minimo = EXCLUDED.preco_minimo,
+			preco_fechamento = EXCLUDED.preco_fechamento,
+			volume = EXCLUDED.volume,
+			numero_negocios = EXCLUDED.numero_negocios,
+			vwap = EXCLUDED.vwap`
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(query, dates)
+		if res.Error != nil {
+			return res.Error
+		}
+		rows = res.RowsAffected
+		// A ticker that disappeared from a reloaded day must not keep its old bar.
+		return tx.Exec(`
+			DELETE FROM daily_bars b
+			WHERE b.data_negocio IN ?
+			  AND NOT EXISTS (
+				SELECT 1 FROM tradings t
+				WHERE t.codigo_instrumento = b.codigo_instrumento AND t.data_negocio = b.data_negocio
+			  )`, dates).Error
+	})
+	return rows, err
+}
+
+func (r *dailyBarRepository) CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).Table("daily_bars").Where("data_negocio < ?", cutoff).Count(&count).Error
+	return count, err
+}
+
+func (r *dailyBarRepository) DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	res := db.WithContext(ctx).Exec(`DELETE FROM daily_bars WHERE data_negocio < ?`, cutoff)
+	return res.RowsAffected, res.Error
+}
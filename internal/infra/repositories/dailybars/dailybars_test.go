@@ -0,0 +1,85 @@
+package dailybars
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	return db
+}
+
+func TestGivenNoDatesWhenMaterializeDatesThenDoesNothing(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewDailyBarRepository()
+
+	// Act
+	rows, err := repo.MaterializeDates(context.Background(), db, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestGivenMissingTablesWhenMaterializeDatesThenReturnsError(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewDailyBarRepository()
+
+	// Act
+	_, err := repo.MaterializeDates(context.Background(), db, []time.Time{time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestGivenMissingTablesWhenMaterializeBeforeThenReturnsError(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewDailyBarRepository()
+
+	// Act
+	_, err := repo.MaterializeBefore(context.Background(), db, time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+type DailyBar struct {
+	CodigoInstrumento string    `gorm:"primaryKey"`
+	DataNegocio       time.Time `gorm:"primaryKey"`
+}
+
+func TestGivenBarsAroundCutoffWhenCountAndDeleteBeforeThenOnlyOlderBarsAreAffected(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&DailyBar{}))
+	cutoff := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	db.Create(&[]DailyBar{
+		{CodigoInstrumento: "PETR4", DataNegocio: cutoff.AddDate(0, 0, -2)},
+		{CodigoInstrumento: "VALE3", DataNegocio: cutoff.AddDate(0, 0, -2)},
+		{CodigoInstrumento: "PETR4", DataNegocio: cutoff},
+	})
+	repo := NewDailyBarRepository()
+
+	// Act
+	counted, errCount := repo.CountBefore(context.Background(), db, cutoff)
+	deleted, errDelete := repo.DeleteBefore(context.Background(), db, cutoff)
+
+	// Assert
+	assert.NoError(t, errCount)
+	assert.NoError(t, errDelete)
+	assert.Equal(t, int64(2), counted)
+	assert.Equal(t, int64(2), deleted)
+	var left int64
+	db.Model(&DailyBar{}).Count(&left)
+	assert.Equal(t, int64(1), left)
+}
@@ -0,0 +1,176 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// refreshSQL recomputes the catalog entry of every ticker traded on the given days from daily_bars.
+const refreshSQL = `
+	INSERT INTO instruments (codigo_instrumento, first_trade_date, last_trade_date, total_trades, updated_at)
+	SELECT codigo_instrumento, MIN(data_negocio), MAX(data_negocio), SUM(numero_negocios), CURRENT_TIMESTAMP
+	FROM daily_bars
+	WHERE codigo_instrumento IN (SELECT codigo_instrumento FROM daily_bars WHERE data_negocio IN ?)
+	GROUP BY codigo_instrumento
+	ON CONFLICT (codigo_instrumento) DO UPDATE SET
+		first_trade_date = excluded.first_trade_date,
+		last_trade_date = excluded.last_trade_date,
+		total_trades = excluded.total_trades,
+		updated_at = excluded.updated_at
+`
+
+// classifySQL stores the classification of one ticker. Fields that do not apply are stored as null.
+const classifySQL = `
+	UPDATE instruments SET asset_class = ?, root = ?, option_type = ?, option_series = ?, strike_code = ?,
+		contract_month = ?, contract_year = ?
+	WHERE codigo_instrumento = ?
+`
+
+// listColumns reads unclassified rows as empty strings and zeros.
+const listColumns = `codigo_instrumento, first_trade_date, last_trade_date, total_trades,
+	COALESCE(asset_class, '') AS asset_class, COALESCE(root, '') AS root, COALESCE(option_type, '') AS option_type,
+	COALESCE(option_series, '') AS option_series, COALESCE(strike_code, '') AS strike_code,
+	COALESCE(contract_month, 0) AS contract_month, COALESCE(contract_year, 0) AS contract_year`
+
+// instrumentRow is a row of the instruments table.
+type instrumentRow struct {
+	CodigoInstrumento string
+	FirstTradeDate    time.Time
+	LastTradeDate     time.Time
+	TotalTrades       int64
+	AssetClass        string
+	Root              string
+	OptionType        string
+	OptionSeries      string
+	StrikeCode        string
+	ContractMonth     int
+	ContractYear      int
+}
+
+type InstrumentRepository interface {
+	// RefreshDates updates the catalog entries of the tickers traded on the given days.
+	RefreshDates(ctx context.Context, db *gorm.DB, dates []time.Time) (int64, error)
+	// ListUnclassified returns the tickers whose classification has not been stored yet.
+	ListUnclassified(ctx context.Context, db *gorm.DB) ([]string, error)
+	// SaveClassifications stores the classification of each ticker in a single transaction.
+	SaveClassifications(ctx context.Context, db *gorm.DB, classes []classifier.Classification) error
+	// List returns a page of the catalog ordered by ticker, together with the number of matches.
+	List(ctx context.Context, db *gorm.DB, filter models.InstrumentFilter) ([]models.Instrument, int64, error)
+}
+
+type instrumentRepository struct{}
+
+func NewInstrumentRepository() InstrumentRepository {
+	return &instrumentRepository{}
+}
+
+func (r *instrumentRepository) RefreshDates(ctx context.Context, db *gorm.DB, dates []time.Time) (int64, error) {
+	if len(dates) == 0 {
+		return 0, nil
+	}
+	res := db.WithContext(ctx).Exec(refreshSQL, dates)
+	return res.RowsAffected, res.Error
+}
+
+func (r *instrumentRepository) List(ctx context.Context, db *gorm.DB, filter models.InstrumentFilter) ([]models.Instrument, int64, error) {
+	query := db.WithContext(ctx).Table("instruments")
+	if filter.Prefix != "" {
+		query = query.Where("codigo_instrumento LIKE ?", filter.Prefix+"%")
+	}
+	if filter.Contains != "" {
+		query = query.Where("codigo_instrumento LIKE ?", "%"+filter.Contains+"%")
+	}
+	if filter.AssetClass != "" {
+		query = query.Where("asset_class = ?", filter.AssetClass)
+	}
+	if filter.Root != "" {
+		query = query.Where("root = ?", filter.Root)
+	}
+	if filter.FirstTradeFrom != nil {
+		query = query.Where("first_trade_date >= ?", *filter.FirstTradeFrom)
+	}
+	if filter.FirstTradeTo != nil {
+		query = query.Where("first_trade_date <= ?", *filter.FirstTradeTo)
+	}
+	if filter.LastTradeFrom != nil {
+		query = query.Where("last_trade_date >= ?", *filter.LastTradeFrom)
+	}
+	if filter.LastTradeTo != nil {
+		query = query.Where("last_trade_date <= ?", *filter.LastTradeTo)
+	}
+	// The filtered query is shared by the count and the page.
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []instrumentRow
+	err := query.Select(listColumns).
+		Order("codigo_instrumento").Limit(filter.Limit).Offset(filter.Offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	instruments := make([]models.Instrument, 0, len(rows))
+	for _, row := range rows {
+		instruments = append(instruments, models.Instrument{
+			Ticker:         row.CodigoInstrumento,
+			FirstTradeDate: row.FirstTradeDate,
+			LastTradeDate:  row.LastTradeDate,
+			TotalTrades:    row.TotalTrades,
+			Class: classifier.Classification{
+				Ticker:       row.CodigoInstrumento,
+				AssetClass:   classifier.AssetClass(row.AssetClass),
+				Root:         row.Root,
+				OptionType:   row.OptionType,
+				OptionSeries: row.OptionSeries,
+				StrikeCode:   row.StrikeCode,
+				Month:        time.Month(row.ContractMonth),
+				Year:         row.ContractYear,
+			},
+		})
+	}
+	return instruments, total, nil
+}
+
+func (r *instrumentRepository) ListUnclassified(ctx context.Context, db *gorm.DB) ([]string, error) {
+	var tickers []string
+	err := db.WithContext(ctx).Table("instruments").Where("asset_class IS NULL").
+		Order("codigo_instrumento").Pluck("codigo_instrumento", &tickers).Error
+	return tickers, err
+}
+
+func (r *instrumentRepository) SaveClassifications(ctx context.Context, db *gorm.DB, classes []classifier.Classification) error {
+	if len(classes) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, c := range classes {
+			err := tx.Exec(classifySQL, string(c.AssetClass), nullIfEmpty(c.Root), nullIfEmpty(c.OptionType),
+				nullIfEmpty(c.OptionSeries), nullIfEmpty(c.StrikeCode), nullIfZero(int(c.Month)), nullIfZero(c.Year),
+				c.Ticker).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullIfZero(n int) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
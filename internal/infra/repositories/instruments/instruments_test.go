@@ -0,0 +1,176 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type DailyBar struct {
+	CodigoInstrumento string    `gorm:"primaryKey"`
+	DataNegocio       time.Time `gorm:"primaryKey"`
+	NumeroNegocios    int64
+}
+
+type Instrument struct {
+	CodigoInstrumento string `gorm:"primaryKey"`
+	FirstTradeDate    time.Time
+	LastTradeDate     time.Time
+	TotalTrades       int64
+	UpdatedAt         time.Time
+	AssetClass        *string
+	Root              *string
+	OptionType        *string
+	OptionSeries      *string
+	StrikeCode        *string
+	ContractMonth     *int
+	ContractYear      *int
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	db.AutoMigrate(&DailyBar{}, &Instrument{})
+	return db
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGivenDailyBarsWhenRefreshDatesThenUpsertsTickersTradedOnThoseDays(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: day(28), NumeroNegocios: 10})
+	db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: day(29), NumeroNegocios: 5})
+	db.Create(&DailyBar{CodigoInstrumento: "VALE3", DataNegocio: day(28), NumeroNegocios: 7})
+	db.Create(&Instrument{CodigoInstrumento: "PETR4", FirstTradeDate: day(28), LastTradeDate: day(28), TotalTrades: 10})
+	repo := NewInstrumentRepository()
+
+	// Act
+	_, err := repo.RefreshDates(context.Background(), db, []time.Time{day(29)})
+
+	// Assert
+	assert.NoError(t, err)
+	var instruments []Instrument
+	db.Order("codigo_instrumento").Find(&instruments)
+	assert.Len(t, instruments, 1)
+	assert.Equal(t, "PETR4", instruments[0].CodigoInstrumento)
+	assert.Equal(t, int64(15), instruments[0].TotalTrades)
+	assert.True(t, day(28).Equal(instruments[0].FirstTradeDate))
+	assert.True(t, day(29).Equal(instruments[0].LastTradeDate))
+}
+
+func TestGivenNoDatesWhenRefreshDatesThenDoesNothing(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewInstrumentRepository()
+
+	// Act
+	rows, err := repo.RefreshDates(context.Background(), db, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestGivenCatalogWhenListThenFiltersAndPaginates(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "PETR3", FirstTradeDate: day(1), LastTradeDate: day(29), TotalTrades: 3})
+	db.Create(&Instrument{CodigoInstrumento: "PETR4", FirstTradeDate: day(1), LastTradeDate: day(29), TotalTrades: 4})
+	db.Create(&Instrument{CodigoInstrumento: "PETRF336", FirstTradeDate: day(10), LastTradeDate: day(15), TotalTrades: 1})
+	db.Create(&Instrument{CodigoInstrumento: "VALE3", FirstTradeDate: day(1), LastTradeDate: day(29), TotalTrades: 9})
+	repo := NewInstrumentRepository()
+	since := day(20)
+
+	// Act
+	page, total, err := repo.List(context.Background(), db, models.InstrumentFilter{
+		Prefix: "PETR", LastTradeFrom: &since, Limit: 1, Offset: 1,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "PETR4", page[0].Ticker)
+	assert.Equal(t, int64(4), page[0].TotalTrades)
+	assert.True(t, day(29).Equal(page[0].LastTradeDate))
+}
+
+func TestGivenSubstringWhenListThenMatchesAnywhereInTicker(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "PETR4", FirstTradeDate: day(1), LastTradeDate: day(29)})
+	db.Create(&Instrument{CodigoInstrumento: "VALE3", FirstTradeDate: day(1), LastTradeDate: day(29)})
+	repo := NewInstrumentRepository()
+
+	// Act
+	page, total, err := repo.List(context.Background(), db, models.InstrumentFilter{Contains: "ALE", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "VALE3", page[0].Ticker)
+}
+
+func TestGivenUnclassifiedInstrumentsWhenSaveClassificationsThenListReturnsThem(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "PETRH250", FirstTradeDate: day(1), LastTradeDate: day(29)})
+	db.Create(&Instrument{CodigoInstrumento: "WDOQ25", FirstTradeDate: day(1), LastTradeDate: day(29)})
+	repo := NewInstrumentRepository()
+	ctx := context.Background()
+
+	// Act
+	pending, err := repo.ListUnclassified(ctx, db)
+	assert.NoError(t, err)
+	classes := make([]classifier.Classification, 0, len(pending))
+	for _, ticker := range pending {
+		classes = append(classes, classifier.Classify(ticker))
+	}
+	err = repo.SaveClassifications(ctx, db, classes)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PETRH250", "WDOQ25"}, pending)
+	left, err := repo.ListUnclassified(ctx, db)
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	page, _, err := repo.List(ctx, db, models.InstrumentFilter{AssetClass: "option", Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "PETR", page[0].Class.Root)
+	assert.Equal(t, "250", page[0].Class.StrikeCode)
+	assert.Equal(t, time.August, page[0].Class.Month)
+	var future Instrument
+	db.First(&future, "codigo_instrumento = ?", "WDOQ25")
+	assert.Nil(t, future.OptionType)
+	assert.Equal(t, 2025, *future.ContractYear)
+}
+
+func TestGivenClassifiedCatalogWhenListByRootThenReturnsOnlyThatRoot(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	option, future := "option", "future"
+	petr, wdo := "PETR", "WDO"
+	db.Create(&Instrument{CodigoInstrumento: "PETRH250", FirstTradeDate: day(1), LastTradeDate: day(29), AssetClass: &option, Root: &petr})
+	db.Create(&Instrument{CodigoInstrumento: "WDOQ25", FirstTradeDate: day(1), LastTradeDate: day(29), AssetClass: &future, Root: &wdo})
+	db.Create(&Instrument{CodigoInstrumento: "VALE3", FirstTradeDate: day(1), LastTradeDate: day(29)})
+	repo := NewInstrumentRepository()
+
+	// Act
+	page, total, err := repo.List(context.Background(), db, models.InstrumentFilter{Root: "WDO", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "WDOQ25", page[0].Ticker)
+	assert.Equal(t, classifier.AssetFuture, page[0].Class.AssetClass)
+}
@@ -0,0 +1,144 @@
+package quality
+
+import (
+	"b3-ingest/internal/domain/quality"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// previousCloseLookbackDays is how far before the range the previous close of a price jump is
+// searched for, in calendar days.
+const previousCloseLookbackDays = 15
+
+// dayCountsSQL counts the trades of each session from daily_bars, which holds one row per ticker
+// and session, so no raw trades are scanned.
+const dayCountsSQL = `
+	SELECT data_negocio, SUM(numero_negocios) AS row_count
+	FROM daily_bars
+	WHERE data_negocio >= ? AND data_negocio <= ?
+	GROUP BY data_negocio
+	ORDER BY data_negocio
+`
+
+// priceJumpsSQL compares each close with the previous session of the same ticker in daily_bars.
+// Options are left out, as their prices routinely move more than any useful threshold.
+const priceJumpsSQL = `
+	SELECT codigo_instrumento, data_negocio, previous_close, preco_fechamento,
+		preco_fechamento / previous_close - 1 AS change
+	FROM (
+		SELECT b.codigo_instrumento, b.data_negocio, b.preco_fechamento,
+			LAG(b.preco_fechamento) OVER (PARTITION BY b.codigo_instrumento ORDER BY b.data_negocio) AS previous_close
+		FROM daily_bars b
+		LEFT JOIN instruments i ON i.codigo_instrumento = b.codigo_instrumento
+		WHERE b.data_negocio >= @lookback AND b.data_negocio <= @end
+			AND COALESCE(i.asset_class, '') <> 'option'
+	) j
+	WHERE data_negocio >= @start AND previous_close > 0
+		AND ABS(preco_fechamento / previous_close - 1) > @threshold
+	ORDER BY ABS(preco_fechamento / previous_close - 1) DESC, codigo_instrumento, data_negocio
+	LIMIT @limit
+`
+
+const duplicateTradesSQL = `
+	SELECT codigo_instrumento, data_negocio, codigo_identificador_negocio, COUNT(*) AS trade_count
+	FROM tradings
+	WHERE data_negocio >= ? AND data_negocio <= ?
+	GROUP BY codigo_instrumento, data_negocio, codigo_identificador_negocio
+	HAVING COUNT(*) > 1
+	ORDER BY data_negocio, codigo_instrumento, codigo_identificador_negocio
+	LIMIT ?
+`
+
+type dayCountRow struct {
+	DataNegocio time.Time
+	RowCount    int64
+}
+
+type priceJumpRow struct {
+	CodigoInstrumento string
+	DataNegocio       time.Time
+	PreviousClose     float64
+	PrecoFechamento   float64
+	Change            float64
+}
+
+type duplicateTradeRow struct {
+	CodigoInstrumento          string
+	DataNegocio                time.Time
+	CodigoIdentificadorNegocio int64
+	TradeCount                 int64
+}
+
+type QualityRepository interface {
+	// GetDayCounts returns the number of trades of every session between startDate and endDate
+	// with daily bars, ordered by date.
+	GetDayCounts(ctx context.Context, db *gorm.DB, startDate, endDate time.Time) ([]quality.DayCount, error)
+	// GetPriceJumps returns up to limit closes between startDate and endDate that moved more than
+	// threshold from the previous close of their ticker, largest moves first.
+	GetPriceJumps(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, threshold float64, limit int) ([]quality.PriceJump, error)
+	// GetDuplicateTrades returns up to limit trade ids stored more than once for a ticker and session.
+	// It scans the raw trades, so callers keep the range short.
+	GetDuplicateTrades(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, limit int) ([]quality.DuplicateTrade, error)
+}
+
+type qualityRepository struct{}
+
+func NewQualityRepository() QualityRepository {
+	return &qualityRepository{}
+}
+
+func (r *qualityRepository) GetDayCounts(ctx context.Context, db *gorm.DB, startDate, endDate time.Time) ([]quality.DayCount, error) {
+	var rows []dayCountRow
+	if err := db.WithContext(ctx).Raw(dayCountsSQL, startDate, endDate).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make([]quality.DayCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, quality.DayCount{Date: row.DataNegocio, Rows: row.RowCount})
+	}
+	return counts, nil
+}
+
+func (r *qualityRepository) GetPriceJumps(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, threshold float64, limit int) ([]quality.PriceJump, error) {
+	var rows []priceJumpRow
+	err := db.WithContext(ctx).Raw(priceJumpsSQL, map[string]interface{}{
+		"lookback":  startDate.AddDate(0, 0, -previousCloseLookbackDays),
+		"start":     startDate,
+		"end":       endDate,
+		"threshold": threshold,
+		"limit":     limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	jumps := make([]quality.PriceJump, 0, len(rows))
+	for _, row := range rows {
+		jumps = append(jumps, quality.PriceJump{
+			Ticker:        row.CodigoInstrumento,
+			Date:          row.DataNegocio,
+			PreviousClose: row.PreviousClose,
+			Close:         row.PrecoFechamento,
+			Change:        row.Change,
+		})
+	}
+	return jumps, nil
+}
+
+func (r *qualityRepository) GetDuplicateTrades(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, limit int) ([]quality.DuplicateTrade, error) {
+	var rows []duplicateTradeRow
+	if err := db.WithContext(ctx).Raw(duplicateTradesSQL, startDate, endDate, limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	duplicates := make([]quality.DuplicateTrade, 0, len(rows))
+	for _, row := range rows {
+		duplicates = append(duplicates, quality.DuplicateTrade{
+			Ticker:  row.CodigoInstrumento,
+			Date:    row.DataNegocio,
+			TradeID: row.CodigoIdentificadorNegocio,
+			Count:   row.TradeCount,
+		})
+	}
+	return duplicates, nil
+}
@@ -0,0 +1,116 @@
+package quality
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type Trading struct {
+	DataNegocio                time.Time
+	CodigoInstrumento          string
+	PrecoNegocio               float64
+	QuantidadeNegociada        int64
+	HoraFechamento             int64
+	CodigoIdentificadorNegocio int64
+}
+
+type DailyBar struct {
+	CodigoInstrumento string    `gorm:"primaryKey"`
+	DataNegocio       time.Time `gorm:"primaryKey"`
+	PrecoFechamento   float64
+	NumeroNegocios    int64
+}
+
+type Instrument struct {
+	CodigoInstrumento string `gorm:"primaryKey"`
+	AssetClass        *string
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	db.AutoMigrate(&Trading{}, &DailyBar{}, &Instrument{})
+	return db
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGivenDailyBarsWhenGetDayCountsThenSumsTradesOfEachSession(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]DailyBar{
+		{DataNegocio: day(25), CodigoInstrumento: "PETR4", NumeroNegocios: 7},
+		{DataNegocio: day(28), CodigoInstrumento: "PETR4", NumeroNegocios: 1},
+		{DataNegocio: day(28), CodigoInstrumento: "VALE3", NumeroNegocios: 1},
+		{DataNegocio: day(29), CodigoInstrumento: "PETR4", NumeroNegocios: 1},
+	})
+	repo := NewQualityRepository()
+
+	// Act
+	counts, err := repo.GetDayCounts(context.Background(), db, day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, counts, 2)
+	assert.True(t, day(28).Equal(counts[0].Date))
+	assert.Equal(t, int64(2), counts[0].Rows)
+	assert.Equal(t, int64(1), counts[1].Rows)
+}
+
+func TestGivenDailyBarsWhenGetPriceJumpsThenReturnsLargeMovesExceptOptions(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	option := "option"
+	db.Create(&[]DailyBar{
+		{CodigoInstrumento: "PETR4", DataNegocio: day(24), PrecoFechamento: 32},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(25), PrecoFechamento: 16},
+		{CodigoInstrumento: "PETR4", DataNegocio: day(28), PrecoFechamento: 16.5},
+		{CodigoInstrumento: "VALE3", DataNegocio: day(25), PrecoFechamento: 50},
+		{CodigoInstrumento: "VALE3", DataNegocio: day(28), PrecoFechamento: 70},
+		{CodigoInstrumento: "PETRH320", DataNegocio: day(25), PrecoFechamento: 0.1},
+		{CodigoInstrumento: "PETRH320", DataNegocio: day(28), PrecoFechamento: 0.5},
+	})
+	db.Create(&Instrument{CodigoInstrumento: "PETRH320", AssetClass: &option})
+	repo := NewQualityRepository()
+
+	// Act
+	jumps, err := repo.GetPriceJumps(context.Background(), db, day(25), day(28), 0.3, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, jumps, 2)
+	assert.Equal(t, "PETR4", jumps[0].Ticker)
+	assert.Equal(t, 32.0, jumps[0].PreviousClose)
+	assert.InDelta(t, -0.5, jumps[0].Change, 1e-9)
+	assert.Equal(t, "VALE3", jumps[1].Ticker)
+	assert.InDelta(t, 0.4, jumps[1].Change, 1e-9)
+}
+
+func TestGivenRepeatedTradeIDWhenGetDuplicateTradesThenReturnsIt(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]Trading{
+		{DataNegocio: day(29), CodigoInstrumento: "PETR4", HoraFechamento: 100000000, CodigoIdentificadorNegocio: 7},
+		{DataNegocio: day(29), CodigoInstrumento: "PETR4", HoraFechamento: 100000500, CodigoIdentificadorNegocio: 7},
+		{DataNegocio: day(29), CodigoInstrumento: "VALE3", HoraFechamento: 100000000, CodigoIdentificadorNegocio: 7},
+		{DataNegocio: day(28), CodigoInstrumento: "PETR4", HoraFechamento: 100000000, CodigoIdentificadorNegocio: 7},
+	})
+	repo := NewQualityRepository()
+
+	// Act
+	duplicates, err := repo.GetDuplicateTrades(context.Background(), db, day(28), day(29), 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, "PETR4", duplicates[0].Ticker)
+	assert.Equal(t, int64(7), duplicates[0].TradeID)
+	assert.Equal(t, int64(2), duplicates[0].Count)
+}
@@ -1,19 +1,196 @@
 package trading
 
 import (
+	"b3-ingest/internal/domain/comparison"
+	"b3-ingest/internal/domain/futures"
+	"b3-ingest/internal/domain/models"
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// QuoteStats aggregates the daily bars of a ticker over a date range.
+// MaxPriceDate and MinPriceDate are nil when the range has no sessions.
 type QuoteStats struct {
+	Sessions       int64
 	MaxPrice       float64
 	MaxDailyVolume int64
+	MinPrice       float64
+	OpenPrice      float64
+	ClosePrice     float64
+	VWAP           float64
+	TotalVolume    int64
+	TradeCount     int64
+	MaxPriceDate   *time.Time
+	MinPriceDate   *time.Time
+}
+
+// quoteStatsRow is a row of the quote statistics query. Dates are read as text because
+// they come out of CASE expressions, whose type is not carried by every driver.
+type quoteStatsRow struct {
+	CodigoInstrumento string
+	Sessions          int64
+	MaxPrice          float64
+	MaxDailyVolume    int64
+	MinPrice          float64
+	OpenPrice         float64
+	ClosePrice        float64
+	Vwap              float64
+	TotalVolume       int64
+	TradeCount        int64
+	MaxPriceDate      *string
+	MinPriceDate      *string
 }
 
 type TradingRepository interface {
-	GetQuoteStats(ctx context.Context, db *gorm.DB, ticker string, startDate time.Time) (QuoteStats, error)
+	GetQuoteStats(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) (QuoteStats, error)
+	TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error)
+	GetQuoteStatsBatch(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string]QuoteStats, error)
+	GetCandles(ctx context.Context, db *gorm.DB, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error)
+	GetDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error)
+	GetTrades(ctx context.Context, db *gorm.DB, query models.TradeQuery) ([]models.Trade, error)
+	// GetDailySummariesWithWarmup is GetDailySummaries preceded by up to warmup sessions before startDate.
+	GetDailySummariesWithWarmup(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, warmup int) ([]models.DailySummary, error)
+	// GetDailyCloses returns the closes of each ticker between startDate and endDate, ordered by date.
+	// Tickers without sessions in the range are absent from the map.
+	GetDailyCloses(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string][]comparison.Observation, error)
+	// GetRankings ranks every instrument traded in the range of query from daily_bars.
+	GetRankings(ctx context.Context, db *gorm.DB, query models.RankingQuery) (models.Rankings, error)
+	// GetPriceLevels bins the trades of ticker between startDate and endDate into price levels
+	// tickSize apart, ordered by price, and returns at most limit of them, the lowest first.
+	GetPriceLevels(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, tickSize float64, limit int) ([]models.PriceLevel, error)
+	GetContractBars(ctx context.Context, db *gorm.DB, root string, startDate, endDate time.Time) ([]futures.Bar, error)
+	// StreamTrades and StreamDailySummaries call fn for each row as it is read from the database,
+	// stopping at the first error, so large ranges are never held in memory.
+	StreamTrades(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.Trade) error) error
+	StreamDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.DailySummary) error) error
+}
+
+// candleRow is a bucket as returned by the candles query, keyed by seconds since midnight.
+type candleRow struct {
+	Bucket int64
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+	Trades int64
+}
+
+// rankingsSQL aggregates the daily bars of every instrument traded in the range. The first %s is
+// the instrument filter, applied to both scans, and the second the ranked expression. prev looks
+// back returnLookbackDays for the session before the range.
+const rankingsSQL = `
+	WITH bars AS (
+		SELECT codigo_instrumento, preco_fechamento, preco_maximo, preco_minimo, volume, numero_negocios,
+			ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY data_negocio DESC) AS rn_last
+		FROM daily_bars
+		WHERE data_negocio >= @start AND data_negocio <= @end%[1]s
+	), prev AS (
+		SELECT codigo_instrumento, preco_fechamento AS prev_close
+		FROM (
+			SELECT codigo_instrumento, preco_fechamento,
+				ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY data_negocio DESC) AS rn
+			FROM daily_bars
+			WHERE data_negocio < @start AND data_negocio >= @lookback%[1]s
+		) p
+		WHERE rn = 1
+	), agg AS (
+		SELECT b.codigo_instrumento,
+			SUM(b.volume) AS volume,
+			SUM(b.numero_negocios) AS trades,
+			MAX(CASE WHEN b.rn_last = 1 THEN b.preco_fechamento END) AS close,
+			MAX(p.prev_close) AS prev_close,
+			MAX(b.preco_maximo) AS high,
+			MIN(b.preco_minimo) AS low
+		FROM bars b
+		LEFT JOIN prev p ON p.codigo_instrumento = b.codigo_instrumento
+		GROUP BY b.codigo_instrumento
+	), ranked AS (
+		SELECT codigo_instrumento, volume, trades, close, value,
+			ROW_NUMBER() OVER (ORDER BY value DESC, codigo_instrumento) AS rank_top,
+			ROW_NUMBER() OVER (ORDER BY value, codigo_instrumento) AS rank_bottom
+		FROM (SELECT agg.*, %[2]s AS value FROM agg) a
+		WHERE value IS NOT NULL
+	)
+	SELECT codigo_instrumento, value, volume, trades, close, rank_top, rank_bottom
+	FROM ranked
+	WHERE rank_top <= @limit OR rank_bottom <= @limit
+	ORDER BY rank_top
+`
+
+// returnLookbackDays is how far before the range the previous close of the return metric is
+// searched for, in calendar days.
+const returnLookbackDays = 15
+
+// rankingExpressions are the ranked expressions over the agg columns of rankingsSQL.
+var rankingExpressions = map[models.RankingMetric]string{
+	models.RankByVolume: "volume",
+	models.RankByTrades: "trades",
+	models.RankByReturn: "close / NULLIF(prev_close, 0) - 1",
+	models.RankByRange:  "high / NULLIF(low, 0) - 1",
+}
+
+// rankingRow is an instrument of rankingsSQL with its position from either end.
+type rankingRow struct {
+	CodigoInstrumento string
+	Value             float64
+	Volume            int64
+	Trades            int64
+	Close             float64
+	RankTop           int
+	RankBottom        int
+}
+
+// dailyCloseRow is the close of one ticker on one session.
+type dailyCloseRow struct {
+	CodigoInstrumento string
+	DataNegocio       time.Time
+	PrecoFechamento   float64
+}
+
+// priceLevelRow is a price bin of the volume profile query, keyed by price over tick size.
+type priceLevelRow struct {
+	Bucket int64
+	Volume int64
+	Trades int64
+}
+
+// contractBarRow is a daily_bars row of a futures contract.
+type contractBarRow struct {
+	CodigoInstrumento string
+	DataNegocio       time.Time
+	PrecoAbertura     float64
+	PrecoMaximo       float64
+	PrecoMinimo       float64
+	PrecoFechamento   float64
+	Volume            int64
+	NumeroNegocios    int64
+}
+
+// tradeRow is a tradings row as returned by the trades query.
+type tradeRow struct {
+	DataNegocio                time.Time
+	HoraFechamento             int64
+	PrecoNegocio               float64
+	QuantidadeNegociada        int64
+	CodigoIdentificadorNegocio int64
+}
+
+// dailySummaryRow is a daily_bars row with the close of the previous session.
+type dailySummaryRow struct {
+	DataNegocio     time.Time
+	PrecoAbertura   float64
+	PrecoMaximo     float64
+	PrecoMinimo     float64
+	PrecoFechamento float64
+	Volume          int64
+	NumeroNegocios  int64
+	Vwap            float64
+	PrevClose       *float64
 }
 
 type tradingRepository struct{}
@@ -22,26 +199,425 @@ func NewTradingRepository() TradingRepository {
 	return &tradingRepository{}
 }
 
-func (r *tradingRepository) GetQuoteStats(ctx context.Context, db *gorm.DB, ticker string, startDate time.Time) (QuoteStats, error) {
-	var stats QuoteStats
+// GetQuoteStats aggregates the daily bars of ticker between startDate and endDate, both inclusive.
+// It returns models.ErrTickerNotFound for unknown tickers and empty stats, with zero Sessions,
+// for known tickers without trades in the range.
+func (r *tradingRepository) GetQuoteStats(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) (QuoteStats, error) {
+	stats, err := r.GetQuoteStatsBatch(ctx, db, []string{ticker}, startDate, endDate)
+	if err != nil {
+		return QuoteStats{}, err
+	}
+	s, ok := stats[ticker]
+	if !ok {
+		return QuoteStats{}, models.ErrTickerNotFound
+	}
+	return s, nil
+}
+
+// TickerExists reports whether ticker has traded on any day stored in daily_bars.
+func (r *tradingRepository) TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error) {
+	var exists bool
+	err := db.WithContext(ctx).Raw(`SELECT EXISTS (SELECT 1 FROM daily_bars WHERE codigo_instrumento = ?)`, ticker).Scan(&exists).Error
+	return exists, err
+}
 
+// GetQuoteStatsBatch aggregates the daily bars of every ticker between startDate and endDate in a
+// single grouped query. The requested tickers are read from the instruments catalog and joined with
+// their bars, so known tickers without sessions in the range come back with zero Sessions and only
+// tickers that were never traded are missing from the result.
+func (r *tradingRepository) GetQuoteStatsBatch(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string]QuoteStats, error) {
+	if len(tickers) == 0 {
+		return map[string]QuoteStats{}, nil
+	}
+	// daily_bars already holds the daily OHLCV of every ticker, so no raw trade is scanned.
+	// The first and last sessions and the sessions of the extremes are picked with ROW_NUMBER.
 	query := `
-		WITH daily_volumes AS (
-			SELECT data_negocio, SUM(quantidade_negociada) AS soma
-			FROM tradings
-			WHERE codigo_instrumento = ? AND data_negocio >= ?
-			GROUP BY data_negocio
+		WITH bars AS (
+			SELECT
+				codigo_instrumento, data_negocio, preco_abertura, preco_maximo, preco_minimo, preco_fechamento, volume, numero_negocios, vwap,
+				ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY data_negocio) AS rn_first,
+				ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY data_negocio DESC) AS rn_last,
+				ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY preco_maximo DESC, data_negocio) AS rn_max,
+				ROW_NUMBER() OVER (PARTITION BY codigo_instrumento ORDER BY preco_minimo, data_negocio) AS rn_min
+			FROM daily_bars
+			WHERE codigo_instrumento IN @tickers AND data_negocio >= @start AND data_negocio <= @end
 		)
-		SELECT 
-			COALESCE(MAX(t.preco_negocio), 0) AS max_price,
-			COALESCE(MAX(dv.soma), 0) AS max_daily_volume
-		FROM tradings t
-		LEFT JOIN daily_volumes dv ON t.data_negocio = dv.data_negocio
-		WHERE t.codigo_instrumento = ? AND t.data_negocio >= ?
+		SELECT
+			i.codigo_instrumento,
+			COUNT(b.codigo_instrumento) AS sessions,
+			COALESCE(MAX(b.preco_maximo), 0) AS max_price,
+			COALESCE(MAX(b.volume), 0) AS max_daily_volume,
+			COALESCE(MIN(b.preco_minimo), 0) AS min_price,
+			COALESCE(MAX(CASE WHEN b.rn_first = 1 THEN b.preco_abertura END), 0) AS open_price,
+			COALESCE(MAX(CASE WHEN b.rn_last = 1 THEN b.preco_fechamento END), 0) AS close_price,
+			COALESCE(SUM(b.vwap * b.volume) / NULLIF(SUM(b.volume), 0), 0) AS vwap,
+			COALESCE(SUM(b.volume), 0) AS total_volume,
+			COALESCE(SUM(b.numero_negocios), 0) AS trade_count,
+			MAX(CASE WHEN b.rn_max = 1 THEN b.data_negocio END) AS max_price_date,
+			MAX(CASE WHEN b.rn_min = 1 THEN b.data_negocio END) AS min_price_date
+		FROM instruments i
+		LEFT JOIN bars b ON b.codigo_instrumento = i.codigo_instrumento
+		WHERE i.codigo_instrumento IN @tickers
+		GROUP BY i.codigo_instrumento
 	`
-	row := db.WithContext(ctx).Raw(query, ticker, startDate, ticker, startDate).Row()
-	if err := row.Scan(&stats.MaxPrice, &stats.MaxDailyVolume); err != nil {
-		return QuoteStats{}, err
+	var rows []quoteStatsRow
+	params := map[string]interface{}{"tickers": tickers, "start": startDate, "end": endDate}
+	if err := db.WithContext(ctx).Raw(query, params).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	stats := make(map[string]QuoteStats, len(rows))
+	for _, row := range rows {
+		s := QuoteStats{
+			Sessions:       row.Sessions,
+			MaxPrice:       row.MaxPrice,
+			MaxDailyVolume: row.MaxDailyVolume,
+			MinPrice:       row.MinPrice,
+			OpenPrice:      row.OpenPrice,
+			ClosePrice:     row.ClosePrice,
+			VWAP:           row.Vwap,
+			TotalVolume:    row.TotalVolume,
+			TradeCount:     row.TradeCount,
+		}
+		var err error
+		if s.MaxPriceDate, err = parseDay(row.MaxPriceDate); err != nil {
+			return nil, err
+		}
+		if s.MinPriceDate, err = parseDay(row.MinPriceDate); err != nil {
+			return nil, err
+		}
+		stats[row.CodigoInstrumento] = s
 	}
 	return stats, nil
 }
+
+// parseDay reads the YYYY-MM-DD prefix of a date rendered as text by the database driver.
+func parseDay(value *string) (*time.Time, error) {
+	if value == nil || len(*value) < len("2006-01-02") {
+		return nil, nil
+	}
+	day, err := time.Parse("2006-01-02", (*value)[:len("2006-01-02")])
+	if err != nil {
+		return nil, err
+	}
+	return &day, nil
+}
+
+// GetCandles buckets the trades of ticker on date by time of day. hora_fechamento is encoded as
+// HHMMSSmmm, so it is converted to seconds since midnight before being divided into buckets.
+func (r *tradingRepository) GetCandles(ctx context.Context, db *gorm.DB, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error) {
+	step := int64(interval / time.Second)
+	query := `
+		WITH t AS (
+			SELECT preco_negocio, quantidade_negociada, hora_fechamento, codigo_identificador_negocio,
+				(((hora_fechamento / 10000000) * 3600 + (hora_fechamento / 100000 % 100) * 60 + (hora_fechamento / 1000 % 100)) / ?) * ? AS bucket
+			FROM tradings
+			WHERE codigo_instrumento = ? AND data_negocio = ?
+		), ranked AS (
+			SELECT bucket, preco_negocio, quantidade_negociada,
+				ROW_NUMBER() OVER (PARTITION BY bucket ORDER BY hora_fechamento, codigo_identificador_negocio) AS rn_first,
+				ROW_NUMBER() OVER (PARTITION BY bucket ORDER BY hora_fechamento DESC, codigo_identificador_negocio DESC) AS rn_last
+			FROM t
+		)
+		SELECT
+			bucket,
+			MAX(CASE WHEN rn_first = 1 THEN preco_negocio END) AS open,
+			MAX(preco_negocio) AS high,
+			MIN(preco_negocio) AS low,
+			MAX(CASE WHEN rn_last = 1 THEN preco_negocio END) AS close,
+			SUM(quantidade_negociada) AS volume,
+			COUNT(*) AS trades
+		FROM ranked
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+	var rows []candleRow
+	if err := db.WithContext(ctx).Raw(query, step, step, ticker, date).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	candles := make([]models.Candle, 0, len(rows))
+	for _, row := range rows {
+		candles = append(candles, models.Candle{
+			Start:  models.SessionTime(date, 0).Add(time.Duration(row.Bucket) * time.Second),
+			Open:   row.Open,
+			High:   row.High,
+			Low:    row.Low,
+			Close:  row.Close,
+			Volume: row.Volume,
+			Trades: row.Trades,
+		})
+	}
+	return candles, nil
+}
+
+// dailySummariesSQL returns one row per session of a ticker up to an end date, then from a start
+// date. The previous close is looked up before the start is applied, so the first day also gets its return.
+const dailySummariesSQL = `
+	SELECT * FROM (
+		SELECT
+			data_negocio, preco_abertura, preco_maximo, preco_minimo, preco_fechamento,
+			volume, numero_negocios, vwap,
+			LAG(preco_fechamento) OVER (ORDER BY data_negocio) AS prev_close
+		FROM daily_bars
+		WHERE codigo_instrumento = ? AND data_negocio <= ?
+	) bars
+	WHERE data_negocio >= ?
+	ORDER BY data_negocio
+`
+
+// GetDailySummaries returns one row per session of ticker between startDate and endDate.
+func (r *tradingRepository) GetDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error) {
+	var rows []dailySummaryRow
+	if err := db.WithContext(ctx).Raw(dailySummariesSQL, ticker, endDate, startDate).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	summaries := make([]models.DailySummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, row.summary())
+	}
+	return summaries, nil
+}
+
+// dailySummariesWarmupSQL ranks the sessions before the start date from the latest back, so only
+// the last warmup of them are kept.
+const dailySummariesWarmupSQL = `
+	SELECT data_negocio, preco_abertura, preco_maximo, preco_minimo, preco_fechamento, volume, numero_negocios, vwap, prev_close
+	FROM (
+		SELECT
+			data_negocio, preco_abertura, preco_maximo, preco_minimo, preco_fechamento,
+			volume, numero_negocios, vwap,
+			LAG(preco_fechamento) OVER (ORDER BY data_negocio) AS prev_close,
+			ROW_NUMBER() OVER (PARTITION BY data_negocio < @start ORDER BY data_negocio DESC) AS rank_before
+		FROM daily_bars
+		WHERE codigo_instrumento = @ticker AND data_negocio <= @end
+	) bars
+	WHERE data_negocio >= @start OR rank_before <= @warmup
+	ORDER BY data_negocio
+`
+
+func (r *tradingRepository) GetDailySummariesWithWarmup(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, warmup int) ([]models.DailySummary, error) {
+	var rows []dailySummaryRow
+	params := map[string]interface{}{"ticker": ticker, "start": startDate, "end": endDate, "warmup": warmup}
+	if err := db.WithContext(ctx).Raw(dailySummariesWarmupSQL, params).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	summaries := make([]models.DailySummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, row.summary())
+	}
+	return summaries, nil
+}
+
+func (r *tradingRepository) StreamDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.DailySummary) error) error {
+	return streamRows(ctx, db, func(row dailySummaryRow) error { return fn(row.summary()) },
+		dailySummariesSQL, ticker, endDate, startDate)
+}
+
+func (r *tradingRepository) StreamTrades(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.Trade) error) error {
+	query := `
+		SELECT data_negocio, hora_fechamento, preco_negocio, quantidade_negociada, codigo_identificador_negocio
+		FROM tradings
+		WHERE codigo_instrumento = ? AND data_negocio >= ? AND data_negocio <= ?
+		ORDER BY data_negocio, hora_fechamento, codigo_identificador_negocio
+	`
+	return streamRows(ctx, db, func(row tradeRow) error { return fn(row.trade()) }, query, ticker, startDate, endDate)
+}
+
+// streamRows runs query and hands each row to fn as soon as it is read.
+func streamRows[T any](ctx context.Context, db *gorm.DB, fn func(T) error, query string, args ...interface{}) error {
+	rows, err := db.WithContext(ctx).Raw(query, args...).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row T
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (row dailySummaryRow) summary() models.DailySummary {
+	summary := models.DailySummary{
+		Date:   row.DataNegocio,
+		Open:   row.PrecoAbertura,
+		High:   row.PrecoMaximo,
+		Low:    row.PrecoMinimo,
+		Close:  row.PrecoFechamento,
+		Volume: row.Volume,
+		Trades: row.NumeroNegocios,
+		VWAP:   row.Vwap,
+	}
+	if row.PrevClose != nil && *row.PrevClose != 0 {
+		ret := row.PrecoFechamento / *row.PrevClose - 1
+		summary.Return = &ret
+	}
+	return summary
+}
+
+func (row tradeRow) trade() models.Trade {
+	return models.Trade{
+		Date:        row.DataNegocio,
+		ClosingTime: row.HoraFechamento,
+		Price:       row.PrecoNegocio,
+		Quantity:    row.QuantidadeNegociada,
+		TradeID:     row.CodigoIdentificadorNegocio,
+	}
+}
+
+// GetTrades returns up to query.Limit trades ordered by (data_negocio, hora_fechamento,
+// codigo_identificador_negocio). Paging uses that key instead of an offset, so every page costs the
+// same no matter how deep it is.
+func (r *tradingRepository) GetTrades(ctx context.Context, db *gorm.DB, query models.TradeQuery) ([]models.Trade, error) {
+	q := db.WithContext(ctx).Table("tradings").
+		Select("data_negocio, hora_fechamento, preco_negocio, quantidade_negociada, codigo_identificador_negocio").
+		Where("codigo_instrumento = ? AND data_negocio >= ? AND data_negocio <= ?", query.Ticker, query.From, query.To)
+	if query.MinQuantity > 0 {
+		q = q.Where("quantidade_negociada >= ?", query.MinQuantity)
+	}
+	if query.After != nil {
+		q = q.Where("(data_negocio, hora_fechamento, codigo_identificador_negocio) > (?, ?, ?)",
+			query.After.Date, query.After.ClosingTime, query.After.TradeID)
+	}
+	var rows []tradeRow
+	err := q.Order("data_negocio, hora_fechamento, codigo_identificador_negocio").Limit(query.Limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	trades := make([]models.Trade, 0, len(rows))
+	for _, row := range rows {
+		trades = append(trades, row.trade())
+	}
+	return trades, nil
+}
+
+// GetContractBars returns the daily bars of every futures contract of root between startDate and
+// endDate. Longer codes sharing the root, such as options on the future, are left out.
+func (r *tradingRepository) GetContractBars(ctx context.Context, db *gorm.DB, root string, startDate, endDate time.Time) ([]futures.Bar, error) {
+	query := `
+		SELECT codigo_instrumento, data_negocio, preco_abertura, preco_maximo, preco_minimo, preco_fechamento, volume, numero_negocios
+		FROM daily_bars
+		WHERE codigo_instrumento LIKE ? AND LENGTH(codigo_instrumento) = ? AND data_negocio >= ? AND data_negocio <= ?
+		ORDER BY data_negocio, codigo_instrumento
+	`
+	var rows []contractBarRow
+	if err := db.WithContext(ctx).Raw(query, root+"%", len(root)+3, startDate, endDate).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	bars := make([]futures.Bar, 0, len(rows))
+	for _, row := range rows {
+		contract, err := futures.ParseContract(row.CodigoInstrumento)
+		if err != nil || contract.Root != root {
+			continue
+		}
+		bars = append(bars, futures.Bar{
+			Contract: contract,
+			Date:     row.DataNegocio,
+			Open:     row.PrecoAbertura,
+			High:     row.PrecoMaximo,
+			Low:      row.PrecoMinimo,
+			Close:    row.PrecoFechamento,
+			Volume:   row.Volume,
+			Trades:   row.NumeroNegocios,
+		})
+	}
+	return bars, nil
+}
+
+// GetPriceLevels rounds each trade price to the nearest multiple of tickSize, so a level covers
+// half a tick on either side of its price.
+func (r *tradingRepository) GetPriceLevels(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, tickSize float64, limit int) ([]models.PriceLevel, error) {
+	query := `
+		SELECT CAST(ROUND(preco_negocio / ?) AS BIGINT) AS bucket, SUM(quantidade_negociada) AS volume, COUNT(*) AS trades
+		FROM tradings
+		WHERE codigo_instrumento = ? AND data_negocio >= ? AND data_negocio <= ?
+		GROUP BY 1
+		ORDER BY 1
+		LIMIT ?
+	`
+	var rows []priceLevelRow
+	if err := db.WithContext(ctx).Raw(query, tickSize, ticker, startDate, endDate, limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	levels := make([]models.PriceLevel, 0, len(rows))
+	for _, row := range rows {
+		levels = append(levels, models.PriceLevel{
+			// Rounded to drop the binary noise of multiplying by tick sizes such as 0.1.
+			Price:  math.Round(float64(row.Bucket)*tickSize*1e8) / 1e8,
+			Volume: row.Volume,
+			Trades: row.Trades,
+		})
+	}
+	return levels, nil
+}
+
+func (r *tradingRepository) GetDailyCloses(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string][]comparison.Observation, error) {
+	query := `
+		SELECT codigo_instrumento, data_negocio, preco_fechamento
+		FROM daily_bars
+		WHERE codigo_instrumento IN ? AND data_negocio >= ? AND data_negocio <= ?
+		ORDER BY codigo_instrumento, data_negocio
+	`
+	var rows []dailyCloseRow
+	if err := db.WithContext(ctx).Raw(query, tickers, startDate, endDate).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	closes := make(map[string][]comparison.Observation)
+	for _, row := range rows {
+		closes[row.CodigoInstrumento] = append(closes[row.CodigoInstrumento], comparison.Observation{
+			Date:  row.DataNegocio,
+			Close: row.PrecoFechamento,
+		})
+	}
+	return closes, nil
+}
+
+func (r *tradingRepository) GetRankings(ctx context.Context, db *gorm.DB, query models.RankingQuery) (models.Rankings, error) {
+	expr, ok := rankingExpressions[query.Metric]
+	if !ok {
+		return models.Rankings{}, fmt.Errorf("invalid ranking metric %q", query.Metric)
+	}
+	filter := ""
+	params := map[string]interface{}{
+		"start":    query.From,
+		"end":      query.To,
+		"lookback": query.From.AddDate(0, 0, -returnLookbackDays),
+		"limit":    query.Limit,
+	}
+	if query.Prefix != "" {
+		filter += " AND codigo_instrumento LIKE @prefix"
+		params["prefix"] = query.Prefix + "%"
+	}
+	if query.AssetClass != "" {
+		filter += " AND codigo_instrumento IN (SELECT codigo_instrumento FROM instruments WHERE asset_class = @asset_class)"
+		params["asset_class"] = query.AssetClass
+	}
+	var rows []rankingRow
+	if err := db.WithContext(ctx).Raw(fmt.Sprintf(rankingsSQL, filter, expr), params).Scan(&rows).Error; err != nil {
+		return models.Rankings{}, err
+	}
+	rankings := models.Rankings{Top: []models.RankingEntry{}, Bottom: make([]models.RankingEntry, query.Limit)}
+	bottom := 0
+	for _, row := range rows {
+		entry := models.RankingEntry{
+			Ticker: row.CodigoInstrumento,
+			Value:  row.Value,
+			Volume: row.Volume,
+			Trades: row.Trades,
+			Close:  row.Close,
+		}
+		if row.RankTop <= query.Limit {
+			rankings.Top = append(rankings.Top, entry)
+		}
+		if row.RankBottom <= query.Limit {
+			rankings.Bottom[row.RankBottom-1] = entry
+			bottom++
+		}
+	}
+	rankings.Bottom = rankings.Bottom[:bottom]
+	return rankings, nil
+}
@@ -1,6 +1,7 @@
 package trading
 
 import (
+	"b3-ingest/internal/domain/models"
 	"context"
 	"testing"
 	"time"
@@ -10,40 +11,54 @@ import (
 	"gorm.io/gorm"
 )
 
+type DailyBar struct {
+	CodigoInstrumento string    `gorm:"primaryKey"`
+	DataNegocio       time.Time `gorm:"primaryKey"`
+	PrecoAbertura     float64
+	PrecoMaximo       float64
+	PrecoMinimo       float64
+	PrecoFechamento   float64
+	Volume            int64
+	NumeroNegocios    int64
+	Vwap              float64
+}
+
 type Trading struct {
-	ID                  uint `gorm:"primaryKey"`
-	DataNegocio         time.Time
-	CodigoInstrumento   string
-	PrecoNegocio        float64
-	QuantidadeNegociada int64
+	DataNegocio                time.Time
+	CodigoInstrumento          string
+	PrecoNegocio               float64
+	QuantidadeNegociada        int64
+	HoraFechamento             int64
+	CodigoIdentificadorNegocio int64
 }
 
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err)
-	db.AutoMigrate(&Trading{})
+	db.AutoMigrate(&DailyBar{}, &Trading{}, &Instrument{})
 	return db
 }
 
 func TestGivenValidDataWhenGetQuoteStatsThenReturnsStats(t *testing.T) {
 	// Arrange
 	db := setupTestDB(t)
-	db.Create(&Trading{
-		DataNegocio:         time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
-		CodigoInstrumento:   "WDOQ25",
-		PrecoNegocio:        100.0,
-		QuantidadeNegociada: 500,
+	db.Create(&Instrument{CodigoInstrumento: "WDOQ25"})
+	db.Create(&DailyBar{
+		DataNegocio:       time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
+		CodigoInstrumento: "WDOQ25",
+		PrecoMaximo:       100.0,
+		Volume:            500,
 	})
-	db.Create(&Trading{
-		DataNegocio:         time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
-		CodigoInstrumento:   "WDOQ25",
-		PrecoNegocio:        200.0,
-		QuantidadeNegociada: 1000,
+	db.Create(&DailyBar{
+		DataNegocio:       time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+		CodigoInstrumento: "WDOQ25",
+		PrecoMaximo:       200.0,
+		Volume:            1000,
 	})
 	repo := NewTradingRepository()
 
 	// Act
-	stats, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC))
+	stats, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
 
 	// Assert
 	assert.NoError(t, err)
@@ -51,28 +66,506 @@ func TestGivenValidDataWhenGetQuoteStatsThenReturnsStats(t *testing.T) {
 	assert.Equal(t, int64(1000), stats.MaxDailyVolume)
 }
 
-func TestGivenNoDataWhenGetQuoteStatsThenReturnsZeroStats(t *testing.T) {
+func TestGivenSeveralSessionsWhenGetQuoteStatsThenReturnsRangeExtremesAndTotals(t *testing.T) {
 	// Arrange
 	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "PETR4"})
+	db.Create(&DailyBar{
+		DataNegocio: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "PETR4",
+		PrecoAbertura: 30, PrecoMaximo: 32, PrecoMinimo: 29, PrecoFechamento: 31, Volume: 100, NumeroNegocios: 4, Vwap: 30,
+	})
+	db.Create(&DailyBar{
+		DataNegocio: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "PETR4",
+		PrecoAbertura: 31, PrecoMaximo: 35, PrecoMinimo: 28, PrecoFechamento: 34, Volume: 300, NumeroNegocios: 6, Vwap: 34,
+	})
+	db.Create(&DailyBar{
+		DataNegocio: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "PETR4",
+		PrecoAbertura: 10, PrecoMaximo: 50, PrecoMinimo: 5, PrecoFechamento: 10, Volume: 999, NumeroNegocios: 9, Vwap: 10,
+	})
 	repo := NewTradingRepository()
 
 	// Act
-	stats, err := repo.GetQuoteStats(context.Background(), db, "FOO", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC))
+	stats, err := repo.GetQuoteStats(context.Background(), db, "PETR4", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Sessions)
+	assert.Equal(t, 35.0, stats.MaxPrice)
+	assert.Equal(t, 28.0, stats.MinPrice)
+	assert.Equal(t, 30.0, stats.OpenPrice)
+	assert.Equal(t, 34.0, stats.ClosePrice)
+	assert.Equal(t, 33.0, stats.VWAP)
+	assert.Equal(t, int64(300), stats.MaxDailyVolume)
+	assert.Equal(t, int64(400), stats.TotalVolume)
+	assert.Equal(t, int64(10), stats.TradeCount)
+	if assert.NotNil(t, stats.MaxPriceDate) && assert.NotNil(t, stats.MinPriceDate) {
+		assert.Equal(t, time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), *stats.MaxPriceDate)
+		assert.Equal(t, time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), *stats.MinPriceDate)
+	}
+}
+
+func TestGivenEndDateWhenGetQuoteStatsThenIgnoresLaterSessions(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "WDOQ25"})
+	db.Create(&DailyBar{
+		DataNegocio: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "WDOQ25",
+		PrecoMaximo: 100.0, Volume: 500,
+	})
+	db.Create(&DailyBar{
+		DataNegocio: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "WDOQ25",
+		PrecoMaximo: 200.0, Volume: 1000,
+	})
+	repo := NewTradingRepository()
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	stats, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", day, day)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, stats.MaxPrice)
+	assert.Equal(t, int64(500), stats.TotalVolume)
+}
+
+func TestGivenUnknownTickerWhenGetQuoteStatsThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewTradingRepository()
+
+	// Act
+	_, err := repo.GetQuoteStats(context.Background(), db, "FOO", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+}
+
+func TestGivenKnownTickerWithoutTradesInRangeWhenGetQuoteStatsThenReturnsEmptyStats(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&Instrument{CodigoInstrumento: "WDOQ25"})
+	db.Create(&DailyBar{DataNegocio: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "WDOQ25", PrecoMaximo: 100.0, Volume: 500})
+	repo := NewTradingRepository()
+
+	// Act
+	stats, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stats.Sessions)
 	assert.Equal(t, 0.0, stats.MaxPrice)
-	assert.Equal(t, int64(0), stats.MaxDailyVolume)
+	assert.Nil(t, stats.MaxPriceDate)
+}
+
+func TestGivenSeveralTickersWhenGetQuoteStatsBatchThenGroupsByTickerAndOmitsUnknown(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&[]Instrument{{CodigoInstrumento: "PETR4"}, {CodigoInstrumento: "VALE3"}, {CodigoInstrumento: "ITUB4"}})
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	db.Create(&DailyBar{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoMaximo: 32, PrecoMinimo: 29, Volume: 100, NumeroNegocios: 4})
+	db.Create(&DailyBar{DataNegocio: day, CodigoInstrumento: "VALE3", PrecoMaximo: 60, PrecoMinimo: 58, Volume: 50, NumeroNegocios: 2})
+	db.Create(&DailyBar{DataNegocio: day.AddDate(0, 0, 1), CodigoInstrumento: "VALE3", PrecoMaximo: 62, PrecoMinimo: 57, Volume: 70, NumeroNegocios: 3})
+	repo := NewTradingRepository()
+
+	// Act
+	stats, err := repo.GetQuoteStatsBatch(context.Background(), db, []string{"PETR4", "VALE3", "ITUB4", "FOO"}, day, day.AddDate(0, 0, 1))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, stats, 3)
+	assert.Equal(t, 32.0, stats["PETR4"].MaxPrice)
+	assert.Equal(t, int64(4), stats["PETR4"].TradeCount)
+	assert.Equal(t, 62.0, stats["VALE3"].MaxPrice)
+	assert.Equal(t, 57.0, stats["VALE3"].MinPrice)
+	assert.Equal(t, int64(120), stats["VALE3"].TotalVolume)
+	assert.Equal(t, int64(0), stats["ITUB4"].Sessions)
+	assert.Nil(t, stats["ITUB4"].MaxPriceDate)
+	assert.NotContains(t, stats, "FOO")
 }
 
 func TestGivenDBErrorWhenGetQuoteStatsThenReturnsError(t *testing.T) {
 	// Arrange
 	db := setupTestDB(t)
-	db.Migrator().DropTable(&Trading{}) // force error
+	db.Migrator().DropTable(&DailyBar{}) // force error
+	repo := NewTradingRepository()
+
+	// Act
+	_, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestGivenTradesWhenGetCandlesThenBucketsByTimeOfDay(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	trade := func(hora int64, id int64, price float64, qty int64) Trading {
+		return Trading{DataNegocio: day, CodigoInstrumento: "WDOQ25", PrecoNegocio: price, QuantidadeNegociada: qty, HoraFechamento: hora, CodigoIdentificadorNegocio: id}
+	}
+	db.Create(&[]Trading{
+		trade(90012345, 2, 101, 10),  // 09:00:12.345
+		trade(90001000, 1, 100, 5),   // 09:00:01.000
+		trade(90459999, 3, 99, 20),   // 09:04:59.999
+		trade(90500000, 4, 105, 1),   // 09:05:00.000
+		trade(100000000, 5, 200, 50), // 10:00:00.000
+	})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "WINQ25", PrecoNegocio: 1, QuantidadeNegociada: 1, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 6})
+	repo := NewTradingRepository()
+
+	// Act
+	candles, err := repo.GetCandles(context.Background(), db, "WDOQ25", day, 5*time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, candles, 3)
+	assert.Equal(t, 9, candles[0].Start.Hour())
+	assert.Equal(t, 0, candles[0].Start.Minute())
+	assert.Equal(t, 100.0, candles[0].Open)
+	assert.Equal(t, 101.0, candles[0].High)
+	assert.Equal(t, 99.0, candles[0].Low)
+	assert.Equal(t, 99.0, candles[0].Close)
+	assert.Equal(t, int64(35), candles[0].Volume)
+	assert.Equal(t, int64(3), candles[0].Trades)
+	assert.Equal(t, 5, candles[1].Start.Minute())
+	assert.Equal(t, 105.0, candles[1].Open)
+	assert.Equal(t, 10, candles[2].Start.Hour())
+}
+
+func TestGivenNoTradesWhenGetCandlesThenReturnsEmpty(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewTradingRepository()
+
+	// Act
+	candles, err := repo.GetCandles(context.Background(), db, "FOO", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, candles)
+}
+
+func TestGivenDailyBarsWhenGetDailySummariesThenReturnsRangeWithReturns(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	bar := func(day int, closePrice float64) DailyBar {
+		return DailyBar{
+			CodigoInstrumento: "PETR4",
+			DataNegocio:       time.Date(2025, 7, day, 0, 0, 0, 0, time.UTC),
+			PrecoAbertura:     closePrice - 1,
+			PrecoMaximo:       closePrice + 1,
+			PrecoMinimo:       closePrice - 2,
+			PrecoFechamento:   closePrice,
+			Volume:            int64(day) * 100,
+			NumeroNegocios:    int64(day),
+			Vwap:              closePrice,
+		}
+	}
+	db.Create(&[]DailyBar{bar(25, 40), bar(28, 50), bar(29, 55), bar(30, 44)})
+	repo := NewTradingRepository()
+
+	// Act
+	days, err := repo.GetDailySummaries(context.Background(), db, "PETR4",
+		time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, days, 2)
+	assert.Equal(t, 28, days[0].Date.Day())
+	assert.InDelta(t, 0.25, *days[0].Return, 1e-9)
+	assert.InDelta(t, 0.10, *days[1].Return, 1e-9)
+	assert.Equal(t, int64(2900), days[1].Volume)
+}
+
+func TestGivenFirstSessionWhenGetDailySummariesThenReturnIsNil(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC), PrecoFechamento: 50})
+	repo := NewTradingRepository()
+
+	// Act
+	days, err := repo.GetDailySummaries(context.Background(), db, "PETR4",
+		time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, days, 1)
+	assert.Nil(t, days[0].Return)
+}
+
+func TestGivenTradesWhenGetTradesPagedWithCursorThenReturnsEveryTradeOnce(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 30, QuantidadeNegociada: 100, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 2})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 31, QuantidadeNegociada: 10, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 3})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 29, QuantidadeNegociada: 200, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 9})
+	db.Create(&Trading{DataNegocio: day.AddDate(0, 0, 1), CodigoInstrumento: "PETR4", PrecoNegocio: 32, QuantidadeNegociada: 300, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 1})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "VALE3", PrecoNegocio: 60, QuantidadeNegociada: 100, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 4})
+	repo := NewTradingRepository()
+	query := models.TradeQuery{Ticker: "PETR4", From: day, To: day.AddDate(0, 0, 1), MinQuantity: 50, Limit: 2}
+
+	// Act
+	first, err := repo.GetTrades(context.Background(), db, query)
+	assert.NoError(t, err)
+	cursor := first[len(first)-1].Cursor()
+	query.After = &cursor
+	second, err := repo.GetTrades(context.Background(), db, query)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, first, 2)
+	assert.Equal(t, int64(9), first[0].TradeID)
+	assert.Equal(t, int64(2), first[1].TradeID)
+	assert.Len(t, second, 1)
+	assert.Equal(t, int64(1), second[0].TradeID)
+	assert.Equal(t, 32.0, second[0].Price)
+	assert.Equal(t, int64(90000000), second[0].ClosingTime)
+}
+
+func TestGivenTradesWhenStreamTradesThenCallsFnInTradeOrder(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 31, QuantidadeNegociada: 10, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 3})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 30, QuantidadeNegociada: 100, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 2})
+	db.Create(&Trading{DataNegocio: day, CodigoInstrumento: "VALE3", PrecoNegocio: 60, QuantidadeNegociada: 100, HoraFechamento: 90000000, CodigoIdentificadorNegocio: 4})
+	repo := NewTradingRepository()
+	var ids []int64
+
+	// Act
+	err := repo.StreamTrades(context.Background(), db, "PETR4", day, day, func(trade models.Trade) error {
+		ids = append(ids, trade.TradeID)
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, ids)
+}
+
+func TestGivenFnErrorWhenStreamDailySummariesThenStopsAndReturnsIt(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	for d := 28; d <= 30; d++ {
+		db.Create(&DailyBar{DataNegocio: time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC), CodigoInstrumento: "PETR4", PrecoFechamento: float64(d)})
+	}
+	repo := NewTradingRepository()
+	calls := 0
+
+	// Act
+	err := repo.StreamDailySummaries(context.Background(), db, "PETR4",
+		time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+		func(day models.DailySummary) error {
+			calls++
+			assert.NotNil(t, day.Return)
+			return assert.AnError
+		})
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGivenFuturesBarsWhenGetContractBarsThenReturnsOnlyContractsOfRoot(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	for _, code := range []string{"WDOQ25", "WDOU25", "WDOQ25C005500", "WINQ25", "DOLQ25"} {
+		db.Create(&DailyBar{DataNegocio: day, CodigoInstrumento: code, PrecoFechamento: 5500, Volume: 10})
+	}
+	repo := NewTradingRepository()
+
+	// Act
+	bars, err := repo.GetContractBars(context.Background(), db, "WDO", day, day)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, bars, 2)
+	assert.Equal(t, "WDOQ25", bars[0].Contract.Code)
+	assert.Equal(t, time.August, bars[0].Contract.Month)
+	assert.Equal(t, "WDOU25", bars[1].Contract.Code)
+	assert.Equal(t, int64(10), bars[1].Volume)
+}
+
+func TestGivenTradesWhenGetPriceLevelsThenBinsVolumeByTickSize(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	trades := []Trading{
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 32.04, QuantidadeNegociada: 100, CodigoIdentificadorNegocio: 1},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 32.11, QuantidadeNegociada: 200, CodigoIdentificadorNegocio: 2},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 32.09, QuantidadeNegociada: 50, CodigoIdentificadorNegocio: 3},
+		{DataNegocio: day, CodigoInstrumento: "PETR4", PrecoNegocio: 32.30, QuantidadeNegociada: 10, CodigoIdentificadorNegocio: 4},
+		{DataNegocio: day, CodigoInstrumento: "VALE3", PrecoNegocio: 32.10, QuantidadeNegociada: 999, CodigoIdentificadorNegocio: 5},
+	}
+	db.Create(&trades)
+	repo := NewTradingRepository()
+
+	// Act
+	levels, err := repo.GetPriceLevels(context.Background(), db, "PETR4", day, day, 0.1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PriceLevel{
+		{Price: 32.0, Volume: 100, Trades: 1},
+		{Price: 32.1, Volume: 250, Trades: 2},
+		{Price: 32.3, Volume: 10, Trades: 1},
+	}, levels)
+
+	// Act
+	levels, err = repo.GetPriceLevels(context.Background(), db, "PETR4", day, day, 0.1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{32.0, 32.1}, []float64{levels[0].Price, levels[1].Price})
+	assert.Len(t, levels, 2)
+}
+
+func TestGivenEarlierSessionsWhenGetDailySummariesWithWarmupThenPrependsOnlyWarmupSessions(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	for d := 21; d <= 29; d++ {
+		db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC), PrecoFechamento: float64(d)})
+	}
+	repo := NewTradingRepository()
+	start := time.Date(2025, 7, 27, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	days, err := repo.GetDailySummariesWithWarmup(context.Background(), db, "PETR4", start, end, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, days, 4)
+	assert.Equal(t, 25.0, days[0].Close)
+	// The first warm-up session still sees the close before it.
+	assert.InDelta(t, 25.0/24-1, *days[0].Return, 1e-9)
+	assert.Equal(t, 28.0, days[3].Close)
+}
+
+func TestGivenDailyBarsWhenGetDailyClosesThenGroupsClosesByTicker(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	d28 := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+	d29 := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: d29, PrecoFechamento: 32.4})
+	db.Create(&DailyBar{CodigoInstrumento: "PETR4", DataNegocio: d28, PrecoFechamento: 32.1})
+	db.Create(&DailyBar{CodigoInstrumento: "PETR3", DataNegocio: d29, PrecoFechamento: 34.9})
+	db.Create(&DailyBar{CodigoInstrumento: "VALE3", DataNegocio: d29, PrecoFechamento: 55.0})
+	repo := NewTradingRepository()
+
+	// Act
+	closes, err := repo.GetDailyCloses(context.Background(), db, []string{"PETR3", "PETR4", "WDOQ25"}, d28, d29)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, closes, 2)
+	assert.Len(t, closes["PETR4"], 2)
+	assert.True(t, d28.Equal(closes["PETR4"][0].Date))
+	assert.Equal(t, 32.4, closes["PETR4"][1].Close)
+	assert.Equal(t, 34.9, closes["PETR3"][0].Close)
+}
+
+type Instrument struct {
+	CodigoInstrumento string `gorm:"primaryKey"`
+	AssetClass        string
+}
+
+func seedRankingBars(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	d25 := time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC)
+	d28 := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+	bars := []DailyBar{
+		{CodigoInstrumento: "PETR4", DataNegocio: d25, PrecoFechamento: 30},
+		{CodigoInstrumento: "PETR4", DataNegocio: d28, PrecoFechamento: 33, PrecoMaximo: 33, PrecoMinimo: 30, Volume: 500, NumeroNegocios: 50},
+		{CodigoInstrumento: "VALE3", DataNegocio: d25, PrecoFechamento: 50},
+		{CodigoInstrumento: "VALE3", DataNegocio: d28, PrecoFechamento: 45, PrecoMaximo: 51, PrecoMinimo: 45, Volume: 900, NumeroNegocios: 20},
+		{CodigoInstrumento: "ITUB4", DataNegocio: d25, PrecoFechamento: 40},
+		{CodigoInstrumento: "ITUB4", DataNegocio: d28, PrecoFechamento: 41, PrecoMaximo: 41, PrecoMinimo: 40, Volume: 100, NumeroNegocios: 80},
+		{CodigoInstrumento: "PETRH250", DataNegocio: d28, PrecoFechamento: 1, PrecoMaximo: 2, PrecoMinimo: 1, Volume: 9999, NumeroNegocios: 5},
+	}
+	db.Create(&bars)
+	db.Create(&[]Instrument{
+		{CodigoInstrumento: "PETR4", AssetClass: "equity"},
+		{CodigoInstrumento: "VALE3", AssetClass: "equity"},
+		{CodigoInstrumento: "ITUB4", AssetClass: "equity"},
+		{CodigoInstrumento: "PETRH250", AssetClass: "option"},
+	})
+	return db
+}
+
+func TestGivenDailyBarsWhenGetRankingsByReturnThenComparesWithPreviousSession(t *testing.T) {
+	// Arrange
+	db := seedRankingBars(t)
+	repo := NewTradingRepository()
+	d28 := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	rankings, err := repo.GetRankings(context.Background(), db, models.RankingQuery{
+		Metric: models.RankByReturn, From: d28, To: d28, Limit: 2,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	// PETRH250 has no previous session, so it has no return.
+	assert.Len(t, rankings.Top, 2)
+	assert.Equal(t, "PETR4", rankings.Top[0].Ticker)
+	assert.InDelta(t, 0.1, rankings.Top[0].Value, 1e-9)
+	assert.Equal(t, "ITUB4", rankings.Top[1].Ticker)
+	assert.Len(t, rankings.Bottom, 2)
+	assert.Equal(t, "VALE3", rankings.Bottom[0].Ticker)
+	assert.InDelta(t, -0.1, rankings.Bottom[0].Value, 1e-9)
+	assert.Equal(t, 45.0, rankings.Bottom[0].Close)
+	assert.Equal(t, "ITUB4", rankings.Bottom[1].Ticker)
+}
+
+func TestGivenAssetClassFilterWhenGetRankingsByVolumeThenRanksOnlyThatClass(t *testing.T) {
+	// Arrange
+	db := seedRankingBars(t)
+	repo := NewTradingRepository()
+	d25 := time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC)
+	d28 := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	rankings, err := repo.GetRankings(context.Background(), db, models.RankingQuery{
+		Metric: models.RankByVolume, From: d25, To: d28, AssetClass: "equity", Limit: 10,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rankings.Top, 3)
+	assert.Equal(t, "VALE3", rankings.Top[0].Ticker)
+	assert.Equal(t, 900.0, rankings.Top[0].Value)
+	assert.Equal(t, "ITUB4", rankings.Bottom[0].Ticker)
+}
+
+func TestGivenPrefixFilterWhenGetRankingsByRangeThenRanksOnlyMatchingTickers(t *testing.T) {
+	// Arrange
+	db := seedRankingBars(t)
+	repo := NewTradingRepository()
+	d28 := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	rankings, err := repo.GetRankings(context.Background(), db, models.RankingQuery{
+		Metric: models.RankByRange, From: d28, To: d28, Prefix: "PETR", Limit: 1,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "PETRH250", rankings.Top[0].Ticker)
+	assert.InDelta(t, 1.0, rankings.Top[0].Value, 1e-9)
+	assert.Equal(t, "PETR4", rankings.Bottom[0].Ticker)
+	assert.InDelta(t, 0.1, rankings.Bottom[0].Value, 1e-9)
+}
+
+func TestGivenInvalidMetricWhenGetRankingsThenReturnsError(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
 	repo := NewTradingRepository()
 
 	// Act
-	_, err := repo.GetQuoteStats(context.Background(), db, "WDOQ25", time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC))
+	_, err := repo.GetRankings(context.Background(), db, models.RankingQuery{Metric: "spread", Limit: 1})
 
 	// Assert
 	assert.Error(t, err)
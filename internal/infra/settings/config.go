@@ -2,6 +2,7 @@ package settings
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v7"
 )
@@ -17,12 +18,72 @@ type DatabaseEnvironment struct {
 	DatabaseSSL      bool   `env:"DATABASE_SSL" envDefault:"true"`
 }
 
+type WatchEnvironment struct {
+	WatchPollInterval time.Duration `env:"WATCH_POLL_INTERVAL" envDefault:"2s"`
+	WatchSettleDelay  time.Duration `env:"WATCH_SETTLE_DELAY" envDefault:"10s"`
+}
+
+type FilterEnvironment struct {
+	IngestTickers     []string `env:"INGEST_TICKERS" envSeparator:","`
+	IngestTickerRegex string   `env:"INGEST_TICKER_REGEX"`
+	IngestSegments    []string `env:"INGEST_SEGMENTS" envSeparator:","`
+}
+
+type RetentionEnvironment struct {
+	RetentionMonths         int  `env:"RETENTION_MONTHS" envDefault:"0"`
+	RetentionKeepAggregates bool `env:"RETENTION_KEEP_AGGREGATES" envDefault:"true"`
+}
+
+type QuoteEnvironment struct {
+	QuoteMaxRangeDays int `env:"QUOTE_MAX_RANGE_DAYS" envDefault:"366"`
+}
+
+type TradesEnvironment struct {
+	TradesMaxPageSize int `env:"TRADES_MAX_PAGE_SIZE" envDefault:"1000"`
+}
+
+type ContinuousEnvironment struct {
+	// ContinuousRollMethod is the default roll rule of /v1/continuous: volume or expiry.
+	ContinuousRollMethod string `env:"CONTINUOUS_ROLL_METHOD" envDefault:"volume"`
+	ContinuousRollDays   int    `env:"CONTINUOUS_ROLL_DAYS" envDefault:"2"`
+}
+
+type BlockTradeEnvironment struct {
+	// BlockTradeMultiple flags trades larger than this multiple of the rolling median trade size,
+	// zero disables the rule.
+	BlockTradeMultiple float64 `env:"BLOCK_TRADE_MULTIPLE" envDefault:"20"`
+	// BlockTradeMinQuantity flags trades of at least this quantity, zero disables the rule.
+	BlockTradeMinQuantity int64 `env:"BLOCK_TRADE_MIN_QUANTITY" envDefault:"0"`
+	// BlockTradeWindow is the number of sessions in the rolling median.
+	BlockTradeWindow int `env:"BLOCK_TRADE_WINDOW" envDefault:"20"`
+}
+
+type QualityEnvironment struct {
+	// QualityTrailingDays is the number of earlier sessions a day's row count is averaged over.
+	QualityTrailingDays int `env:"QUALITY_TRAILING_DAYS" envDefault:"20"`
+	// QualityRowDeviation flags days whose row count differs from the trailing average by more
+	// than this fraction.
+	QualityRowDeviation float64 `env:"QUALITY_ROW_DEVIATION" envDefault:"0.5"`
+	// QualityPriceJump flags closes that moved more than this fraction from the previous close.
+	QualityPriceJump float64 `env:"QUALITY_PRICE_JUMP" envDefault:"0.3"`
+}
+
 // Config stores application configurations.
 type Config struct {
 	CSVPath        string `env:"CSV_PATH,required" envDefault:"./bundle/b3files"`
 	AppPort        string `env:"APP_DEFAULT_PORT" envDefault:"8000"`
 	APPName        string `env:"APP_NAME" envDefault:"b3-ingest"`
 	IngestionCores int    `env:"INGESTION_CORES" envDefault:"6"`
+	// PartitionGranularity is the range size of each tradings partition: monthly or daily.
+	PartitionGranularity string `env:"PARTITION_GRANULARITY" envDefault:"monthly"`
+	WatchEnvironment
+	FilterEnvironment
+	RetentionEnvironment
+	QuoteEnvironment
+	TradesEnvironment
+	ContinuousEnvironment
+	BlockTradeEnvironment
+	QualityEnvironment
 	DatabaseEnvironment
 }
 
@@ -40,10 +101,44 @@ func (c *Config) DSN() string {
 // LoadConfig loads environment variables.
 func LoadConfig() *Config {
 	cfg := &Config{
-		CSVPath:        GetEnvs().CSVPath,
-		AppPort:        GetEnvs().AppPort,
-		APPName:        GetEnvs().APPName,
-		IngestionCores: GetEnvs().IngestionCores,
+		CSVPath:              GetEnvs().CSVPath,
+		AppPort:              GetEnvs().AppPort,
+		APPName:              GetEnvs().APPName,
+		IngestionCores:       GetEnvs().IngestionCores,
+		PartitionGranularity: GetEnvs().PartitionGranularity,
+		WatchEnvironment: WatchEnvironment{
+			WatchPollInterval: GetEnvs().WatchPollInterval,
+			WatchSettleDelay:  GetEnvs().WatchSettleDelay,
+		},
+		FilterEnvironment: FilterEnvironment{
+			IngestTickers:     GetEnvs().IngestTickers,
+			IngestTickerRegex: GetEnvs().IngestTickerRegex,
+			IngestSegments:    GetEnvs().IngestSegments,
+		},
+		RetentionEnvironment: RetentionEnvironment{
+			RetentionMonths:         GetEnvs().RetentionMonths,
+			RetentionKeepAggregates: GetEnvs().RetentionKeepAggregates,
+		},
+		QuoteEnvironment: QuoteEnvironment{
+			QuoteMaxRangeDays: GetEnvs().QuoteMaxRangeDays,
+		},
+		TradesEnvironment: TradesEnvironment{
+			TradesMaxPageSize: GetEnvs().TradesMaxPageSize,
+		},
+		ContinuousEnvironment: ContinuousEnvironment{
+			ContinuousRollMethod: GetEnvs().ContinuousRollMethod,
+			ContinuousRollDays:   GetEnvs().ContinuousRollDays,
+		},
+		BlockTradeEnvironment: BlockTradeEnvironment{
+			BlockTradeMultiple:    GetEnvs().BlockTradeMultiple,
+			BlockTradeMinQuantity: GetEnvs().BlockTradeMinQuantity,
+			BlockTradeWindow:      GetEnvs().BlockTradeWindow,
+		},
+		QualityEnvironment: QualityEnvironment{
+			QualityTrailingDays: GetEnvs().QualityTrailingDays,
+			QualityRowDeviation: GetEnvs().QualityRowDeviation,
+			QualityPriceJump:    GetEnvs().QualityPriceJump,
+		},
 		DatabaseEnvironment: DatabaseEnvironment{
 			DatabaseName:     GetEnvs().DatabaseName,
 			DatabasePassword: GetEnvs().DatabasePassword,
@@ -55,10 +150,17 @@ func LoadConfig() *Config {
 	return cfg
 }
 
+// defaultQuoteRangeDays is the range /quote covers when data_inicio is missing, so a smaller
+// QUOTE_MAX_RANGE_DAYS would reject the default request.
+const defaultQuoteRangeDays = 7
+
 func LoadEnvs() error {
 	if err := env.Parse(&envs); err != nil {
 		return err
 	}
+	if envs.QuoteMaxRangeDays < 0 || (envs.QuoteMaxRangeDays > 0 && envs.QuoteMaxRangeDays <= defaultQuoteRangeDays) {
+		return fmt.Errorf("QUOTE_MAX_RANGE_DAYS must be 0 (no limit) or greater than %d, got %d", defaultQuoteRangeDays, envs.QuoteMaxRangeDays)
+	}
 	return nil
 
 }
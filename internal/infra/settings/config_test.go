@@ -3,6 +3,7 @@ package settings
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -18,6 +19,23 @@ func TestGivenValidEnvVarsWhenLoadEnvsThenConfigIsPopulated(t *testing.T) {
 	os.Setenv("APP_DEFAULT_PORT", "9999")
 	os.Setenv("APP_NAME", "testapp")
 	os.Setenv("INGESTION_CORES", "2")
+	os.Setenv("PARTITION_GRANULARITY", "daily")
+	os.Setenv("WATCH_POLL_INTERVAL", "5s")
+	os.Setenv("WATCH_SETTLE_DELAY", "1m")
+	os.Setenv("INGEST_TICKERS", "WDO*,PETR4")
+	os.Setenv("INGEST_SEGMENTS", "futures")
+	os.Setenv("RETENTION_MONTHS", "6")
+	os.Setenv("RETENTION_KEEP_AGGREGATES", "false")
+	os.Setenv("QUOTE_MAX_RANGE_DAYS", "90")
+	os.Setenv("TRADES_MAX_PAGE_SIZE", "250")
+	os.Setenv("CONTINUOUS_ROLL_METHOD", "expiry")
+	os.Setenv("CONTINUOUS_ROLL_DAYS", "3")
+	os.Setenv("BLOCK_TRADE_MULTIPLE", "12.5")
+	os.Setenv("BLOCK_TRADE_MIN_QUANTITY", "100000")
+	os.Setenv("BLOCK_TRADE_WINDOW", "10")
+	os.Setenv("QUALITY_TRAILING_DAYS", "15")
+	os.Setenv("QUALITY_ROW_DEVIATION", "0.4")
+	os.Setenv("QUALITY_PRICE_JUMP", "0.25")
 
 	// Act
 	err := LoadEnvs()
@@ -34,6 +52,48 @@ func TestGivenValidEnvVarsWhenLoadEnvsThenConfigIsPopulated(t *testing.T) {
 	assert.Equal(t, "9999", cfg.AppPort)
 	assert.Equal(t, "testapp", cfg.APPName)
 	assert.Equal(t, 2, cfg.IngestionCores)
+	assert.Equal(t, "daily", cfg.PartitionGranularity)
+	assert.Equal(t, 5*time.Second, cfg.WatchPollInterval)
+	assert.Equal(t, time.Minute, cfg.WatchSettleDelay)
+	assert.Equal(t, []string{"WDO*", "PETR4"}, cfg.IngestTickers)
+	assert.Equal(t, []string{"futures"}, cfg.IngestSegments)
+	assert.Equal(t, 6, cfg.RetentionMonths)
+	assert.False(t, cfg.RetentionKeepAggregates)
+	assert.Equal(t, 90, cfg.QuoteMaxRangeDays)
+	assert.Equal(t, 250, cfg.TradesMaxPageSize)
+	assert.Equal(t, "expiry", cfg.ContinuousRollMethod)
+	assert.Equal(t, 3, cfg.ContinuousRollDays)
+	assert.Equal(t, 12.5, cfg.BlockTradeMultiple)
+	assert.Equal(t, int64(100000), cfg.BlockTradeMinQuantity)
+	assert.Equal(t, 10, cfg.BlockTradeWindow)
+	assert.Equal(t, 15, cfg.QualityTrailingDays)
+	assert.Equal(t, 0.4, cfg.QualityRowDeviation)
+	assert.Equal(t, 0.25, cfg.QualityPriceJump)
+}
+
+func TestGivenZeroTradesPageSizeWhenLoadEnvsThenLeavesItToTheServer(t *testing.T) {
+	// Arrange
+	t.Setenv("TRADES_MAX_PAGE_SIZE", "0")
+
+	// Act
+	err := LoadEnvs()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, GetEnvs().TradesMaxPageSize)
+}
+
+func TestGivenQuoteRangeWithinDefaultWindowWhenLoadEnvsThenReturnsError(t *testing.T) {
+	for _, days := range []string{"-1", "1", "7"} {
+		// Arrange
+		t.Setenv("QUOTE_MAX_RANGE_DAYS", days)
+
+		// Act
+		err := LoadEnvs()
+
+		// Assert
+		assert.ErrorContains(t, err, "QUOTE_MAX_RANGE_DAYS")
+	}
 }
 
 func TestGivenConfigWhenDSNThenReturnsCorrectString(t *testing.T) {
@@ -0,0 +1,26 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type BlockTradeService interface {
+	ListBlockTrades(ctx context.Context, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error)
+}
+
+type blockTradeService struct {
+	repo blocktrades.BlockTradeRepository
+	db   *gorm.DB
+}
+
+func NewBlockTradeService(repo blocktrades.BlockTradeRepository, db *gorm.DB) BlockTradeService {
+	return &blockTradeService{repo: repo, db: db}
+}
+
+func (s *blockTradeService) ListBlockTrades(ctx context.Context, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error) {
+	return s.repo.List(ctx, s.db, filter)
+}
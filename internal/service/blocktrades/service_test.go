@@ -0,0 +1,37 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockBlockTradeRepository struct {
+	blocktrades.BlockTradeRepository
+	gotFilter models.BlockTradeFilter
+}
+
+func (m *mockBlockTradeRepository) List(ctx context.Context, db *gorm.DB, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error) {
+	m.gotFilter = filter
+	return []models.BlockTrade{{Ticker: "PETR4", Reason: models.BlockReasonMedian}}, 3, nil
+}
+
+func TestListBlockTradesGivenFilterWhenCalledThenDelegatesToRepository(t *testing.T) {
+	// Arrange
+	repo := &mockBlockTradeRepository{}
+	svc := NewBlockTradeService(repo, nil)
+	filter := models.BlockTradeFilter{Ticker: "PETR4", Limit: 100}
+
+	// Act
+	page, total, err := svc.ListBlockTrades(context.Background(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, filter, repo.gotFilter)
+	assert.Len(t, page, 1)
+	assert.Equal(t, int64(3), total)
+}
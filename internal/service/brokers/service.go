@@ -0,0 +1,95 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/brokers"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BrokerService interface {
+	// LoadBrokers reads a participant code to broker name mapping and stores it, returning the
+	// number of brokers read.
+	LoadBrokers(ctx context.Context, r io.Reader) (int, error)
+	GetBrokerFlow(ctx context.Context, ticker string, startDate, endDate time.Time, top int) (models.BrokerFlowReport, error)
+}
+
+type brokerService struct {
+	repo   brokers.BrokerRepository
+	trades trading.TradingRepository
+	db     *gorm.DB
+}
+
+func NewBrokerService(repo brokers.BrokerRepository, trades trading.TradingRepository, db *gorm.DB) BrokerService {
+	return &brokerService{repo: repo, trades: trades, db: db}
+}
+
+func (s *brokerService) LoadBrokers(ctx context.Context, r io.Reader) (int, error) {
+	list, err := readBrokers(r)
+	if err != nil {
+		return 0, err
+	}
+	return len(list), s.repo.SaveBrokers(ctx, s.db, list)
+}
+
+// GetBrokerFlow returns the flow of every participant of ticker between startDate and endDate with
+// the top buyers and sellers. Unknown tickers return models.ErrTickerNotFound.
+func (s *brokerService) GetBrokerFlow(ctx context.Context, ticker string, startDate, endDate time.Time, top int) (models.BrokerFlowReport, error) {
+	flows, err := s.repo.GetFlows(ctx, s.db, ticker, startDate, endDate)
+	if err != nil {
+		return models.BrokerFlowReport{}, err
+	}
+	if len(flows) == 0 {
+		exists, err := s.trades.TickerExists(ctx, s.db, ticker)
+		if err != nil {
+			return models.BrokerFlowReport{}, err
+		}
+		if !exists {
+			return models.BrokerFlowReport{}, models.ErrTickerNotFound
+		}
+		flows = []models.BrokerFlow{}
+	}
+	return models.NewBrokerFlowReport(flows, top), nil
+}
+
+// readBrokers parses a semicolon separated file of participant codes and broker names, one broker
+// per line. A first line whose code is not a number is taken as a header and skipped.
+func readBrokers(r io.Reader) ([]models.Broker, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	var list []models.Broker
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected code;name", line)
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil && line == 1 {
+			continue
+		}
+		if err != nil || code < 0 {
+			return nil, fmt.Errorf("line %d: invalid participant code %q", line, record[0])
+		}
+		name := strings.TrimSpace(record[1])
+		if name == "" {
+			return nil, fmt.Errorf("line %d: empty broker name", line)
+		}
+		list = append(list, models.Broker{Code: code, Name: name})
+	}
+}
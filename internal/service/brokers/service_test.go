@@ -0,0 +1,120 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/brokers"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockBrokerRepository struct {
+	brokers.BrokerRepository
+	flows []models.BrokerFlow
+	saved []models.Broker
+}
+
+func (m *mockBrokerRepository) SaveBrokers(ctx context.Context, db *gorm.DB, list []models.Broker) error {
+	m.saved = list
+	return nil
+}
+
+func (m *mockBrokerRepository) GetFlows(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.BrokerFlow, error) {
+	return m.flows, nil
+}
+
+type mockTradingRepository struct {
+	trading.TradingRepository
+	exists bool
+}
+
+func (m *mockTradingRepository) TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error) {
+	return m.exists, nil
+}
+
+func TestLoadBrokersGivenFileWithHeaderWhenCalledThenSavesBrokers(t *testing.T) {
+	// Arrange
+	repo := &mockBrokerRepository{}
+	svc := NewBrokerService(repo, &mockTradingRepository{}, nil)
+	file := "codigo;nome\n3; XP INVESTIMENTOS \n8;UBS BB\n"
+
+	// Act
+	n, err := svc.LoadBrokers(context.Background(), strings.NewReader(file))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []models.Broker{{Code: 3, Name: "XP INVESTIMENTOS"}, {Code: 8, Name: "UBS BB"}}, repo.saved)
+}
+
+func TestLoadBrokersGivenInvalidLineWhenCalledThenReturnsErrorWithoutSaving(t *testing.T) {
+	cases := []string{
+		"3;XP\nabc;UBS\n",
+		"3;XP\n8\n",
+		"3;XP\n8;\n",
+	}
+	for _, file := range cases {
+		// Arrange
+		repo := &mockBrokerRepository{}
+		svc := NewBrokerService(repo, &mockTradingRepository{}, nil)
+
+		// Act
+		_, err := svc.LoadBrokers(context.Background(), strings.NewReader(file))
+
+		// Assert
+		assert.ErrorContains(t, err, "line 2")
+		assert.Nil(t, repo.saved)
+	}
+}
+
+func TestGetBrokerFlowGivenFlowsWhenCalledThenReturnsTopBuyersAndSellers(t *testing.T) {
+	// Arrange
+	repo := &mockBrokerRepository{flows: []models.BrokerFlow{
+		{Code: 3, BuyQuantity: 300, SellQuantity: 50},
+		{Code: 8, BuyQuantity: 50, SellQuantity: 100},
+		{Code: 72, SellQuantity: 200},
+	}}
+	svc := NewBrokerService(repo, &mockTradingRepository{}, nil)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	report, err := svc.GetBrokerFlow(context.Background(), "PETR4", day, day, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, report.Brokers, 3)
+	assert.Equal(t, 3, report.TopBuyers[0].Code)
+	assert.Len(t, report.TopSellers, 1)
+	assert.Equal(t, 72, report.TopSellers[0].Code)
+}
+
+func TestGetBrokerFlowGivenUnknownTickerWhenCalledThenReturnsTickerNotFound(t *testing.T) {
+	// Arrange
+	svc := NewBrokerService(&mockBrokerRepository{}, &mockTradingRepository{exists: false}, nil)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := svc.GetBrokerFlow(context.Background(), "XXXX9", day, day, 5)
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+}
+
+func TestGetBrokerFlowGivenKnownTickerWithoutCodesWhenCalledThenReturnsEmptyReport(t *testing.T) {
+	// Arrange
+	svc := NewBrokerService(&mockBrokerRepository{}, &mockTradingRepository{exists: true}, nil)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	report, err := svc.GetBrokerFlow(context.Background(), "PETR4", day, day, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, report.Brokers)
+	assert.Empty(t, report.Brokers)
+}
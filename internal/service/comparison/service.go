@@ -0,0 +1,48 @@
+package comparison
+
+import (
+	"b3-ingest/internal/domain/comparison"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ComparisonService interface {
+	Compare(ctx context.Context, tickers []string, benchmark string, policy comparison.GapPolicy, startDate, endDate time.Time) (comparison.Comparison, error)
+}
+
+type comparisonService struct {
+	repo trading.TradingRepository
+	db   *gorm.DB
+}
+
+func NewComparisonService(repo trading.TradingRepository, db *gorm.DB) ComparisonService {
+	return &comparisonService{repo: repo, db: db}
+}
+
+// Compare reads the closes of tickers between startDate and endDate in one query and aligns them.
+// A ticker that was never traded returns an error wrapping models.ErrTickerNotFound; one without
+// sessions in the range just has empty series.
+func (s *comparisonService) Compare(ctx context.Context, tickers []string, benchmark string, policy comparison.GapPolicy, startDate, endDate time.Time) (comparison.Comparison, error) {
+	closes, err := s.repo.GetDailyCloses(ctx, s.db, tickers, startDate, endDate)
+	if err != nil {
+		return comparison.Comparison{}, err
+	}
+	for _, ticker := range tickers {
+		if _, ok := closes[ticker]; ok {
+			continue
+		}
+		exists, err := s.repo.TickerExists(ctx, s.db, ticker)
+		if err != nil {
+			return comparison.Comparison{}, err
+		}
+		if !exists {
+			return comparison.Comparison{}, fmt.Errorf("%w: %s", models.ErrTickerNotFound, ticker)
+		}
+	}
+	return comparison.Compare(tickers, benchmark, policy, closes), nil
+}
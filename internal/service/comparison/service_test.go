@@ -0,0 +1,80 @@
+package comparison
+
+import (
+	"b3-ingest/internal/domain/comparison"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockTradingRepository struct {
+	trading.TradingRepository
+	closes map[string][]comparison.Observation
+	known  map[string]bool
+}
+
+func (m *mockTradingRepository) GetDailyCloses(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string][]comparison.Observation, error) {
+	return m.closes, nil
+}
+
+func (m *mockTradingRepository) TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error) {
+	return m.known[ticker], nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCompareGivenClosesWhenCalledThenAlignsTickers(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{closes: map[string][]comparison.Observation{
+		"PETR3": {{Date: day(28), Close: 34.5}, {Date: day(29), Close: 34.9}},
+		"PETR4": {{Date: day(29), Close: 32.4}},
+	}}
+	svc := NewComparisonService(repo, nil)
+
+	// Act
+	c, err := svc.Compare(context.Background(), []string{"PETR3", "PETR4"}, "PETR3", comparison.GapNull, day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, c.Dates, 2)
+	assert.Nil(t, c.Closes["PETR4"][0])
+	assert.InDelta(t, 2.5, *c.Spread[1], 1e-9)
+}
+
+func TestCompareGivenKnownTickerWithoutSessionsWhenCalledThenReturnsEmptySeries(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{
+		closes: map[string][]comparison.Observation{"PETR3": {{Date: day(29), Close: 34.9}}},
+		known:  map[string]bool{"PETR4": true},
+	}
+	svc := NewComparisonService(repo, nil)
+
+	// Act
+	c, err := svc.Compare(context.Background(), []string{"PETR3", "PETR4"}, "PETR3", comparison.GapForwardFill, day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, c.Closes["PETR4"][0])
+}
+
+func TestCompareGivenUnknownTickerWhenCalledThenReturnsTickerNotFound(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{closes: map[string][]comparison.Observation{
+		"PETR3": {{Date: day(29), Close: 34.9}},
+	}}
+	svc := NewComparisonService(repo, nil)
+
+	// Act
+	_, err := svc.Compare(context.Background(), []string{"PETR3", "XXXX9"}, "PETR3", comparison.GapForwardFill, day(28), day(29))
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+	assert.Contains(t, err.Error(), "XXXX9")
+}
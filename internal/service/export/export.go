@@ -0,0 +1,58 @@
+package export
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"fmt"
+	"io"
+
+	"gorm.io/gorm"
+)
+
+type ExportService interface {
+	// Export writes the rows selected by req to w as they are read from the database and returns
+	// how many were written.
+	Export(ctx context.Context, w io.Writer, req models.ExportRequest) (int64, error)
+}
+
+type exportService struct {
+	repo trading.TradingRepository
+	db   *gorm.DB
+}
+
+func NewExportService(repo trading.TradingRepository, db *gorm.DB) ExportService {
+	return &exportService{repo: repo, db: db}
+}
+
+func (s *exportService) Export(ctx context.Context, w io.Writer, req models.ExportRequest) (int64, error) {
+	switch req.Dataset {
+	case models.ExportTrades:
+		return export(req.Format, w, newTradeRecord, func(fn func(models.Trade) error) error {
+			return s.repo.StreamTrades(ctx, s.db, req.Ticker, req.From, req.To, fn)
+		})
+	case models.ExportDailyBars:
+		return export(req.Format, w, newDailyRecord, func(fn func(models.DailySummary) error) error {
+			return s.repo.StreamDailySummaries(ctx, s.db, req.Ticker, req.From, req.To, fn)
+		})
+	default:
+		return 0, fmt.Errorf("unsupported export dataset %q", req.Dataset)
+	}
+}
+
+// export streams the rows produced by stream through convert into a writer for format.
+func export[M any, T record](format models.ExportFormat, w io.Writer, convert func(M) T, stream func(func(M) error) error) (int64, error) {
+	rw, err := newRowWriter[T](format, w)
+	if err != nil {
+		return 0, err
+	}
+	var rows int64
+	err = stream(func(m M) error {
+		rows++
+		return rw.Write(convert(m))
+	})
+	if err != nil {
+		return rows, err
+	}
+	return rows, rw.Close()
+}
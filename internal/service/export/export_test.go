@@ -0,0 +1,135 @@
+package export
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockTradingRepository struct {
+	trading.TradingRepository
+	trades []models.Trade
+	days   []models.DailySummary
+}
+
+func (m *mockTradingRepository) StreamTrades(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.Trade) error) error {
+	for _, t := range m.trades {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockTradingRepository) StreamDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, fn func(models.DailySummary) error) error {
+	for _, d := range m.days {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testRepository() *mockTradingRepository {
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	ret := 0.02
+	return &mockTradingRepository{
+		trades: []models.Trade{
+			{Date: day, ClosingTime: 100000125, Price: 30.5, Quantity: 100, TradeID: 1},
+			{Date: day, ClosingTime: 100001000, Price: 30.75, Quantity: 200, TradeID: 2},
+		},
+		days: []models.DailySummary{
+			{Date: day, Open: 30, High: 31, Low: 29, Close: 30.5, Volume: 300, Trades: 2, VWAP: 30.6},
+			{Date: day.AddDate(0, 0, 1), Open: 30.5, High: 32, Low: 30, Close: 31.11, Volume: 100, Trades: 1, VWAP: 31, Return: &ret},
+		},
+	}
+}
+
+func TestExportGivenTradesWhenFormatIsCSVThenWritesHeaderAndRows(t *testing.T) {
+	// Arrange
+	svc := NewExportService(testRepository(), nil)
+	var out bytes.Buffer
+
+	// Act
+	rows, err := svc.Export(context.Background(), &out, models.ExportRequest{Ticker: "PETR4", Dataset: models.ExportTrades, Format: models.ExportCSV})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	assert.Equal(t, "date,time,price,quantity,trade_id\n"+
+		"2025-07-29,10:00:00.125,30.5,100,1\n"+
+		"2025-07-29,10:00:01.000,30.75,200,2\n", out.String())
+}
+
+func TestExportGivenNoRowsWhenFormatIsCSVThenWritesHeaderOnly(t *testing.T) {
+	// Arrange
+	svc := NewExportService(&mockTradingRepository{}, nil)
+	var out bytes.Buffer
+
+	// Act
+	rows, err := svc.Export(context.Background(), &out, models.ExportRequest{Ticker: "PETR4", Dataset: models.ExportDailyBars, Format: models.ExportCSV})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+	assert.Equal(t, "date,open,high,low,close,volume,trades,vwap,daily_return\n", out.String())
+}
+
+func TestExportGivenDailyBarsWhenFormatIsNDJSONThenWritesOneObjectPerLine(t *testing.T) {
+	// Arrange
+	svc := NewExportService(testRepository(), nil)
+	var out bytes.Buffer
+
+	// Act
+	rows, err := svc.Export(context.Background(), &out, models.ExportRequest{Ticker: "PETR4", Dataset: models.ExportDailyBars, Format: models.ExportNDJSON})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	var second dailyRecord
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "2025-07-30", second.Date)
+	assert.Equal(t, 31.11, second.Close)
+	assert.InDelta(t, 0.02, *second.Return, 1e-9)
+}
+
+func TestExportGivenDailyBarsWhenFormatIsParquetThenFileCanBeReadBack(t *testing.T) {
+	// Arrange
+	svc := NewExportService(testRepository(), nil)
+	var out bytes.Buffer
+
+	// Act
+	rows, err := svc.Export(context.Background(), &out, models.ExportRequest{Ticker: "PETR4", Dataset: models.ExportDailyBars, Format: models.ExportParquet})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	read, err := parquet.Read[dailyRecord](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, read, 2)
+	assert.Nil(t, read[0].Return)
+	assert.Equal(t, int64(300), read[0].Volume)
+	assert.InDelta(t, 0.02, *read[1].Return, 1e-9)
+}
+
+func TestExportGivenUnknownDatasetWhenCalledThenReturnsError(t *testing.T) {
+	// Arrange
+	svc := NewExportService(testRepository(), nil)
+
+	// Act
+	_, err := svc.Export(context.Background(), &bytes.Buffer{}, models.ExportRequest{Dataset: "quotes", Format: models.ExportCSV})
+
+	// Assert
+	assert.Error(t, err)
+}
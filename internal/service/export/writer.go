@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"b3-ingest/internal/domain/models"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// rowGroupSize bounds how many rows the Parquet writer buffers before flushing a row group.
+const rowGroupSize = 64 * 1024
+
+// record is an exported row. header and values give its CSV representation, the struct tags
+// its NDJSON and Parquet ones.
+type record interface {
+	header() []string
+	values() []string
+}
+
+type tradeRecord struct {
+	Date     string  `json:"date" parquet:"date"`
+	Time     string  `json:"time" parquet:"time"`
+	Price    float64 `json:"price" parquet:"price"`
+	Quantity int64   `json:"quantity" parquet:"quantity"`
+	TradeID  int64   `json:"trade_id" parquet:"trade_id"`
+}
+
+func newTradeRecord(t models.Trade) tradeRecord {
+	return tradeRecord{
+		Date:     t.Date.Format("2006-01-02"),
+		Time:     models.SessionTime(t.Date, t.ClosingTime).Format("15:04:05.000"),
+		Price:    t.Price,
+		Quantity: t.Quantity,
+		TradeID:  t.TradeID,
+	}
+}
+
+func (tradeRecord) header() []string {
+	return []string{"date", "time", "price", "quantity", "trade_id"}
+}
+
+func (r tradeRecord) values() []string {
+	return []string{r.Date, r.Time, formatFloat(r.Price), strconv.FormatInt(r.Quantity, 10), strconv.FormatInt(r.TradeID, 10)}
+}
+
+type dailyRecord struct {
+	Date   string   `json:"date" parquet:"date"`
+	Open   float64  `json:"open" parquet:"open"`
+	High   float64  `json:"high" parquet:"high"`
+	Low    float64  `json:"low" parquet:"low"`
+	Close  float64  `json:"close" parquet:"close"`
+	Volume int64    `json:"volume" parquet:"volume"`
+	Trades int64    `json:"trades" parquet:"trades"`
+	VWAP   float64  `json:"vwap" parquet:"vwap"`
+	Return *float64 `json:"daily_return" parquet:"daily_return,optional"`
+}
+
+func newDailyRecord(d models.DailySummary) dailyRecord {
+	return dailyRecord{
+		Date:   d.Date.Format("2006-01-02"),
+		Open:   d.Open,
+		High:   d.High,
+		Low:    d.Low,
+		Close:  d.Close,
+		Volume: d.Volume,
+		Trades: d.Trades,
+		VWAP:   d.VWAP,
+		Return: d.Return,
+	}
+}
+
+func (dailyRecord) header() []string {
+	return []string{"date", "open", "high", "low", "close", "volume", "trades", "vwap", "daily_return"}
+}
+
+func (r dailyRecord) values() []string {
+	ret := ""
+	if r.Return != nil {
+		ret = formatFloat(*r.Return)
+	}
+	return []string{r.Date, formatFloat(r.Open), formatFloat(r.High), formatFloat(r.Low), formatFloat(r.Close),
+		strconv.FormatInt(r.Volume, 10), strconv.FormatInt(r.Trades, 10), formatFloat(r.VWAP), ret}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// rowWriter encodes records one at a time. Close must be called to flush the output, even when
+// no record was written.
+type rowWriter[T record] interface {
+	Write(T) error
+	Close() error
+}
+
+func newRowWriter[T record](format models.ExportFormat, w io.Writer) (rowWriter[T], error) {
+	switch format {
+	case models.ExportCSV:
+		return &csvWriter[T]{w: csv.NewWriter(w)}, nil
+	case models.ExportNDJSON:
+		buf := bufio.NewWriter(w)
+		return &ndjsonWriter[T]{buf: buf, enc: json.NewEncoder(buf)}, nil
+	case models.ExportParquet:
+		return &parquetWriter[T]{w: parquet.NewGenericWriter[T](w, parquet.MaxRowsPerRowGroup(rowGroupSize))}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type csvWriter[T record] struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvWriter[T]) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+	var zero T
+	return c.w.Write(zero.header())
+}
+
+func (c *csvWriter[T]) Write(r T) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.w.Write(r.values())
+}
+
+func (c *csvWriter[T]) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter[T record] struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter[T]) Write(r T) error {
+	return n.enc.Encode(r)
+}
+
+func (n *ndjsonWriter[T]) Close() error {
+	return n.buf.Flush()
+}
+
+type parquetWriter[T record] struct {
+	w *parquet.GenericWriter[T]
+}
+
+func (p *parquetWriter[T]) Write(r T) error {
+	_, err := p.w.Write([]T{r})
+	return err
+}
+
+func (p *parquetWriter[T]) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,50 @@
+package indicators
+
+import (
+	"b3-ingest/internal/domain/indicators"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IndicatorService interface {
+	GetIndicator(ctx context.Context, ticker string, kind indicators.Kind, window int, startDate, endDate time.Time) ([]indicators.Point, error)
+}
+
+type indicatorService struct {
+	repo trading.TradingRepository
+	db   *gorm.DB
+}
+
+func NewIndicatorService(repo trading.TradingRepository, db *gorm.DB) IndicatorService {
+	return &indicatorService{repo: repo, db: db}
+}
+
+// GetIndicator returns one point per session between startDate and endDate. The sessions before
+// startDate that the window needs are read as well, so the first point is only empty when the
+// ticker has no earlier history. Unknown tickers return models.ErrTickerNotFound.
+func (s *indicatorService) GetIndicator(ctx context.Context, ticker string, kind indicators.Kind, window int, startDate, endDate time.Time) ([]indicators.Point, error) {
+	bars, err := s.repo.GetDailySummariesWithWarmup(ctx, s.db, ticker, startDate, endDate, indicators.Warmup(kind, window))
+	if err != nil {
+		return nil, err
+	}
+	if len(bars) == 0 {
+		exists, err := s.repo.TickerExists(ctx, s.db, ticker)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, models.ErrTickerNotFound
+		}
+	}
+	points := indicators.Compute(kind, window, bars)
+	for i, p := range points {
+		if !p.Date.Before(startDate) {
+			return points[i:], nil
+		}
+	}
+	return []indicators.Point{}, nil
+}
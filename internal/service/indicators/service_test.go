@@ -0,0 +1,78 @@
+package indicators
+
+import (
+	"b3-ingest/internal/domain/indicators"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockTradingRepository struct {
+	trading.TradingRepository
+	bars      []models.DailySummary
+	exists    bool
+	gotWarmup int
+}
+
+func (m *mockTradingRepository) GetDailySummariesWithWarmup(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, warmup int) ([]models.DailySummary, error) {
+	m.gotWarmup = warmup
+	return m.bars, nil
+}
+
+func (m *mockTradingRepository) TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error) {
+	return m.exists, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetIndicatorGivenWarmupSessionsWhenCalledThenFirstPointHasFullWindow(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{bars: []models.DailySummary{
+		{Date: day(24), Close: 1},
+		{Date: day(25), Close: 2},
+		{Date: day(28), Close: 3},
+		{Date: day(29), Close: 4},
+	}}
+	svc := NewIndicatorService(repo, nil)
+
+	// Act
+	points, err := svc.GetIndicator(context.Background(), "PETR4", indicators.SMA, 3, day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, repo.gotWarmup)
+	assert.Len(t, points, 2)
+	assert.Equal(t, day(28), points[0].Date)
+	assert.InDelta(t, 2, *points[0].Value, 1e-9)
+	assert.InDelta(t, 3, *points[1].Value, 1e-9)
+}
+
+func TestGetIndicatorGivenUnknownTickerWhenCalledThenReturnsTickerNotFound(t *testing.T) {
+	// Arrange
+	svc := NewIndicatorService(&mockTradingRepository{}, nil)
+
+	// Act
+	_, err := svc.GetIndicator(context.Background(), "XXXX9", indicators.SMA, 3, day(28), day(29))
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+}
+
+func TestGetIndicatorGivenKnownTickerWithoutSessionsWhenCalledThenReturnsNoPoints(t *testing.T) {
+	// Arrange
+	svc := NewIndicatorService(&mockTradingRepository{exists: true}, nil)
+
+	// Act
+	points, err := svc.GetIndicator(context.Background(), "PETR4", indicators.RSI, 14, day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, points)
+}
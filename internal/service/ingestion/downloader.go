@@ -3,10 +3,12 @@ package ingestion
 import (
 	"archive/zip"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -93,6 +95,9 @@ func unzip(src, dest string, logf func(string, ...interface{})) error {
 	defer r.Close()
 	for _, f := range r.File {
 		fpath := filepath.Join(dest, f.Name)
+		if !insideDir(dest, fpath) {
+			return fmt.Errorf("zip entry %q resolves outside %s", f.Name, dest)
+		}
 		if f.FileInfo().IsDir() {
 			os.MkdirAll(fpath, f.Mode())
 			continue
@@ -119,3 +124,13 @@ func unzip(src, dest string, logf func(string, ...interface{})) error {
 	}
 	return nil
 }
+
+// insideDir reports whether path, already joined and cleaned, stays inside dir. It rejects the
+// "../" entry names of a zip-slip archive.
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
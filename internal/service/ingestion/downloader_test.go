@@ -1,6 +1,7 @@
 package ingestion
 
 import (
+	"archive/zip"
 	"context"
 	"os"
 	"path/filepath"
@@ -55,3 +56,26 @@ func TestUnzipGivenValidZipWhenCalledThenExtractsFiles(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 }
+
+func TestUnzipGivenEntryOutsideDestWhenCalledThenRefusesToExtract(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	dest := filepath.Join(root, "extract")
+	zipPath := filepath.Join(root, "slip.zip")
+	f, err := os.Create(zipPath)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	entry, err := w.Create("../evil.csv")
+	assert.NoError(t, err)
+	_, err = entry.Write([]byte("x"))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+
+	// Act
+	err = unzip(zipPath, dest, func(string, ...interface{}) {})
+
+	// Assert
+	assert.ErrorContains(t, err, "outside")
+	assert.NoFileExists(t, filepath.Join(root, "evil.csv"))
+}
@@ -0,0 +1,114 @@
+package ingestion
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Market segments recognised by the ingestion filter. They are derived from the ticker code.
+const (
+	SegmentEquities = "equities"
+	SegmentOptions  = "options"
+	SegmentFutures  = "futures"
+	SegmentOther    = "other"
+)
+
+// FilterConfig describes which instruments are kept during ingestion.
+// Tickers holds exact codes or glob patterns (e.g. WDO*), Regex an optional regular
+// expression and Segments the market segments to keep. Empty fields do not restrict anything.
+type FilterConfig struct {
+	Tickers  []string
+	Regex    string
+	Segments []string
+}
+
+// Filter decides whether a row is loaded based on its instrument code.
+type Filter struct {
+	exact    map[string]bool
+	globs    []string
+	re       *regexp.Regexp
+	segments map[string]bool
+}
+
+// NewFilter builds a Filter from cfg. It returns nil when cfg does not restrict anything.
+func NewFilter(cfg FilterConfig) (*Filter, error) {
+	f := &Filter{exact: map[string]bool{}, segments: map[string]bool{}}
+	for _, t := range cfg.Tickers {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if strings.ContainsAny(t, "*?[") {
+			if _, err := path.Match(t, ""); err != nil {
+				return nil, fmt.Errorf("invalid ticker pattern %q: %w", t, err)
+			}
+			f.globs = append(f.globs, t)
+			continue
+		}
+		f.exact[t] = true
+	}
+	if cfg.Regex != "" {
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticker regex %q: %w", cfg.Regex, err)
+		}
+		f.re = re
+	}
+	for _, s := range cfg.Segments {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		switch s {
+		case SegmentEquities, SegmentOptions, SegmentFutures, SegmentOther:
+			f.segments[s] = true
+		default:
+			return nil, fmt.Errorf("invalid market segment %q", s)
+		}
+	}
+	if len(f.exact) == 0 && len(f.globs) == 0 && f.re == nil && len(f.segments) == 0 {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// Match reports whether ticker passes the filter. Ticker rules (exact, glob, regex) are
+// alternatives, and the segment rule must hold as well when configured. A nil Filter matches everything.
+func (f *Filter) Match(ticker string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.segments) > 0 && !f.segments[SegmentOf(ticker)] {
+		return false
+	}
+	if len(f.exact) == 0 && len(f.globs) == 0 && f.re == nil {
+		return true
+	}
+	if f.exact[ticker] {
+		return true
+	}
+	for _, g := range f.globs {
+		if ok, _ := path.Match(g, ticker); ok {
+			return true
+		}
+	}
+	return f.re != nil && f.re.MatchString(ticker)
+}
+
+// SegmentOf derives the market segment of a B3 instrument from its code. Shares, units, ETFs and
+// BDRs, including their fractional lots, are all equities.
+func SegmentOf(ticker string) string {
+	switch classifier.Classify(ticker).AssetClass {
+	case classifier.AssetOption:
+		return SegmentOptions
+	case classifier.AssetFuture:
+		return SegmentFutures
+	case classifier.AssetOther:
+		return SegmentOther
+	default:
+		return SegmentEquities
+	}
+}
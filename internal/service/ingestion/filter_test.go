@@ -0,0 +1,107 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFilterGivenEmptyConfigWhenCreatedThenReturnsNilFilterMatchingEverything(t *testing.T) {
+	// Arrange
+	cfg := FilterConfig{Tickers: []string{" "}}
+
+	// Act
+	f, err := NewFilter(cfg)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+	assert.True(t, f.Match("PETR4"))
+}
+
+func TestFilterGivenExactAndGlobTickersWhenMatchedThenKeepsOnlyListedInstruments(t *testing.T) {
+	// Arrange
+	f, err := NewFilter(FilterConfig{Tickers: []string{"WDO*", "win*", "PETR4"}})
+	assert.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, f.Match("WDOQ25"))
+	assert.True(t, f.Match("WINV25"))
+	assert.True(t, f.Match("PETR4"))
+	assert.False(t, f.Match("PETR3"))
+	assert.False(t, f.Match("DI1F26"))
+}
+
+func TestFilterGivenRegexWhenMatchedThenKeepsMatchingInstruments(t *testing.T) {
+	// Arrange
+	f, err := NewFilter(FilterConfig{Regex: `^DI1[FN]\d{2}$`})
+	assert.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, f.Match("DI1F26"))
+	assert.False(t, f.Match("DI1J26"))
+}
+
+func TestFilterGivenSegmentWhenMatchedThenCombinesWithTickerRules(t *testing.T) {
+	// Arrange
+	f, err := NewFilter(FilterConfig{Tickers: []string{"PETR*"}, Segments: []string{"equities"}})
+	assert.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, f.Match("PETR4"))
+	assert.False(t, f.Match("PETRH250"))
+	assert.False(t, f.Match("WDOQ25"))
+}
+
+func TestFilterGivenFuturesSegmentWhenMatchedThenKeepsLongDatedContracts(t *testing.T) {
+	// Arrange
+	f, err := NewFilter(FilterConfig{Segments: []string{"futures"}})
+	assert.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, f.Match("DI1F26"))
+	assert.True(t, f.Match("DI1F33"))
+	assert.True(t, f.Match("DI1F35"))
+	assert.False(t, f.Match("AAPL34"))
+}
+
+func TestNewFilterGivenInvalidConfigWhenCreatedThenReturnsError(t *testing.T) {
+	// Arrange
+	cases := []FilterConfig{
+		{Tickers: []string{"WDO["}},
+		{Regex: "("},
+		{Segments: []string{"crypto"}},
+	}
+
+	for _, cfg := range cases {
+		// Act
+		_, err := NewFilter(cfg)
+
+		// Assert
+		assert.Error(t, err)
+	}
+}
+
+func TestSegmentOfGivenB3CodesWhenClassifiedThenReturnsSegment(t *testing.T) {
+	// Arrange
+	cases := map[string]string{
+		"PETR4":    SegmentEquities,
+		"PETR4F":   SegmentEquities,
+		"KLBN11":   SegmentEquities,
+		"AAPL34":   SegmentEquities,
+		"WDOQ25":   SegmentFutures,
+		"DI1F26":   SegmentFutures,
+		"DI1F33":   SegmentFutures,
+		"DI1F35":   SegmentFutures,
+		"PETRH250": SegmentOptions,
+		"XYZ":      SegmentOther,
+	}
+
+	for code, expected := range cases {
+		// Act
+		got := SegmentOf(code)
+
+		// Assert
+		assert.Equal(t, expected, got, code)
+	}
+}
@@ -1,9 +1,12 @@
 package ingestion
 
 import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
 	"bufio"
 	"context"
 	"encoding/csv"
+	"fmt"
 	"os"
 	"runtime"
 	"strconv"
@@ -11,41 +14,113 @@ import (
 	"sync"
 	"time"
 
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"b3-ingest/internal/infra/repositories/dailybars"
+	"b3-ingest/internal/infra/repositories/instruments"
 	"b3-ingest/internal/infra/settings"
 	"b3-ingest/internal/logger"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"gorm.io/gorm"
 )
 
+// cleanupTimeout bounds the rollback work done after the ingestion context is cancelled.
+const cleanupTimeout = 30 * time.Second
+
+// stagingLockID is the advisory lock key held by a run for as long as it uses tradings_unlogged.
+const stagingLockID int64 = 0x6233696e67657374
+
+// Columns of the buyer and seller participant codes in the B3 trade files.
+const (
+	buyerColumn  = 9
+	sellerColumn = 10
+)
+
+// stagingDB is the part of the connection pool used to stage, merge and discard trades, so the
+// load can run against a fake database in tests.
+type stagingDB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type Service struct {
-	DB  *gorm.DB
-	DSN string
-	Log *logger.Logger
+	DB          *gorm.DB
+	DSN         string
+	Log         *logger.Logger
+	Filter      *Filter
+	Granularity Granularity
+	// BlockTrades flags the large trades of every loaded date; a disabled rule skips detection.
+	BlockTrades models.BlockTradeRule
+	bars        dailybars.DailyBarRepository
+	instruments instruments.InstrumentRepository
+	blocks      blocktrades.BlockTradeRepository
+}
+
+// Report summarizes the outcome of an ingestion run.
+type Report struct {
+	FilesTotal     int
+	FilesProcessed int
+	FilesFailed    int
+	FilesSkipped   int
+	RowsCopied     int64
+	RowsFiltered   int64
+	Aborted        bool
+}
+
+// fileStats counts the rows copied and dropped by the filter for a single file.
+type fileStats struct {
+	copied   int64
+	filtered int64
 }
 
 func NewService(db *gorm.DB, dsn string, log *logger.Logger) *Service {
-	return &Service{DB: db, DSN: dsn, Log: log}
+	return &Service{
+		DB:          db,
+		DSN:         dsn,
+		Log:         log,
+		bars:        dailybars.NewDailyBarRepository(),
+		instruments: instruments.NewInstrumentRepository(),
+		blocks:      blocktrades.NewBlockTradeRepository(),
+	}
 }
 
-func (s *Service) IngestFromCSV(dir string) error {
+// IngestFromCSV loads every file in dir into the database. It can be cancelled via ctx:
+// no new files are started, in-flight COPYs are aborted and the staged rows are discarded.
+func (s *Service) IngestFromCSV(ctx context.Context, dir string) (Report, error) {
+	var report Report
 	s.Log.Info("Starting CSV ingestion...")
-	pool, err := pgxpool.New(context.Background(), s.DSN)
+	pool, err := pgxpool.New(ctx, s.DSN)
 	if err != nil {
 		s.Log.Error("Error connecting to database: %v", err)
-		return err
+		return report, err
 	}
 	defer pool.Close()
 
-	if err := s.prepareDatabase(pool); err != nil {
-		return err
+	unlock, err := s.lockStaging(ctx, pool)
+	if err != nil {
+		s.Log.Error("Error locking staging table: %v", err)
+		return report, err
+	}
+	defer unlock()
+
+	return s.loadFiles(ctx, dir, pool)
+}
+
+// loadFiles stages every file in dir and merges them once all are copied. A cancelled ctx discards
+// the staged rows instead of merging them.
+func (s *Service) loadFiles(ctx context.Context, dir string, pool stagingDB) (Report, error) {
+	var report Report
+	if err := s.prepareDatabase(ctx, pool); err != nil {
+		return report, err
 	}
 
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		s.Log.Error("Error listing files in directory %s: %v", dir, err)
-		return err
+		return report, err
 	}
 
 	var wg sync.WaitGroup
@@ -60,65 +135,112 @@ func (s *Service) IngestFromCSV(dir string) error {
 	var firstErr error
 	var mu sync.Mutex
 
+loop:
 	for _, f := range files {
 		if f.IsDir() {
 			continue
 		}
+		report.FilesTotal++
+		select {
+		case <-ctx.Done():
+			report.FilesSkipped++
+			continue loop
+		case sem <- struct{}{}:
+		}
 		wg.Add(1)
-		sem <- struct{}{}
 		go func(f os.DirEntry) {
 			defer wg.Done()
 			defer func() { <-sem }()
-			if err := s.processFile(f.Name(), dir, pool); err != nil {
-				mu.Lock()
+			stats, err := s.processFile(ctx, f.Name(), dir, pool)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.FilesFailed++
 				if firstErr == nil {
 					firstErr = err
 				}
 				s.Log.Error("Error processing file %s: %v", f.Name(), err)
-				mu.Unlock()
+				return
 			}
+			report.FilesProcessed++
+			report.RowsCopied += stats.copied
+			report.RowsFiltered += stats.filtered
 		}(f)
 	}
 	wg.Wait()
 
-	return s.finalizeIngestion(pool, firstErr)
+	if ctx.Err() != nil {
+		report.Aborted = true
+		s.abortIngestion(pool)
+		return report, ctx.Err()
+	}
+
+	return report, s.finalizeIngestion(ctx, pool, firstErr)
 }
 
-func (s *Service) prepareDatabase(pool *pgxpool.Pool) error {
-
-	s.Log.Info("Creating UNLOGGED table if necessary...")
-	sql := `
-		CREATE UNLOGGED TABLE IF NOT EXISTS tradings_unlogged (
-			data_negocio date,
-			codigo_instrumento text,
-			preco_negocio numeric,
-			quantidade_negociada bigint,
-			hora_fechamento bigint,
-			codigo_identificador_negocio bigint
-		);
-	`
-	_, err := pool.Exec(context.Background(), sql)
+// lockStaging waits until no other -load or -watch run is using the staging table, which every run
+// truncates and merges as a whole, and keeps it for this run. The advisory lock is held on its own
+// connection until the returned function releases it.
+func (s *Service) lockStaging(ctx context.Context, pool *pgxpool.Pool) (func(), error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, stagingLockID).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	if !locked {
+		s.Log.Info("Waiting for another ingestion run to release the staging table...")
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, stagingLockID); err != nil {
+			conn.Release()
+			return nil, err
+		}
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, stagingLockID); err != nil {
+			// Closing the session is the only other way to release the lock.
+			s.Log.Error("Error unlocking staging table: %v", err)
+			conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}, nil
+}
+
+// prepareDatabase empties the staging table. Tables and indexes are created by the schema migrations.
+func (s *Service) prepareDatabase(ctx context.Context, pool stagingDB) error {
+	s.Log.Info("Truncating staging table...")
+	_, err := pool.Exec(ctx, `TRUNCATE tradings_unlogged;`)
 
 	return err
 }
 
-func (s *Service) processFile(fileName, dir string, pool *pgxpool.Pool) error {
+func (s *Service) processFile(ctx context.Context, fileName, dir string, pool stagingDB) (fileStats, error) {
+	var stats fileStats
 	path := dir + "/" + fileName
 	s.Log.Info("Processing: %s", path)
 	file, err := os.Open(path)
 	if err != nil {
-		return err
+		return stats, err
 	}
 	defer file.Close()
 
 	r := csv.NewReader(bufio.NewReaderSize(file, 1<<20))
 	r.Comma = ';'
 	if _, err := r.Read(); err != nil {
-		return err
+		return stats, err
 	}
 
+	// Rows rejected by the filter are skipped here so they never reach COPY.
 	copySrc := pgx.CopyFromFunc(func() ([]any, error) {
 		record, err := r.Read()
+		for err == nil && !s.Filter.Match(record[1]) {
+			stats.filtered++
+			record, err = r.Read()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -126,48 +248,146 @@ func (s *Service) processFile(fileName, dir string, pool *pgxpool.Pool) error {
 		qtd, _ := strconv.Atoi(record[4])
 		dataNegocio, _ := time.Parse("2006-01-02", record[8])
 		horaFechamentoInt, _ := strconv.ParseInt(record[5], 10, 64)
-		return []any{dataNegocio, record[1], preco, qtd, horaFechamentoInt, record[6]}, nil
+		return []any{dataNegocio, record[1], preco, qtd, horaFechamentoInt, record[6],
+			participantCode(record, buyerColumn), participantCode(record, sellerColumn)}, nil
 	})
 
-	_, err = pool.CopyFrom(context.Background(), pgx.Identifier{"tradings_unlogged"},
-		[]string{"data_negocio", "codigo_instrumento", "preco_negocio", "quantidade_negociada", "hora_fechamento", "codigo_identificador_negocio"}, copySrc)
+	stats.copied, err = pool.CopyFrom(ctx, pgx.Identifier{"tradings_unlogged"},
+		[]string{"data_negocio", "codigo_instrumento", "preco_negocio", "quantidade_negociada", "hora_fechamento", "codigo_identificador_negocio",
+			"codigo_participante_comprador", "codigo_participante_vendedor"}, copySrc)
 
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 	s.Log.Debug("Memory after %s: %.2f MB", fileName, float64(m.Alloc)/1024/1024)
-	return err
+	if stats.filtered > 0 {
+		s.Log.Info("Filtered %d rows from %s", stats.filtered, fileName)
+	}
+	return stats, err
 }
 
-func (s *Service) finalizeIngestion(pool *pgxpool.Pool, firstErr error) error {
-	s.Log.Info("Finalizing ingestion and running final SQLs...")
-	/*
-		included codigo_identificador_negocio to make sure the unique constraint is applied correctly.
-		This is important to ensure that the ingestion process does not fail due to duplicate entries.
-		create a idx_tradings_ticker_data to improve query performance.
-	*/
+func (s *Service) finalizeIngestion(ctx context.Context, pool stagingDB, firstErr error) error {
+	s.Log.Info("Finalizing ingestion and merging loaded partitions...")
 
-	finalSQL :=
-		`INSERT INTO tradings (data_negocio, codigo_instrumento, preco_negocio, quantidade_negociada, hora_fechamento, codigo_identificador_negocio)
-		 SELECT data_negocio, codigo_instrumento, preco_negocio, quantidade_negociada, hora_fechamento, codigo_identificador_negocio
-		 FROM tradings_unlogged;
+	// The merges run inside a transaction so a cancellation halfway through leaves tradings untouched.
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		s.Log.Error("Error starting final transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(context.Background())
 
-		drop table IF EXISTS tradings_unlogged;
+	dates, err := s.mergeStagedPartitions(ctx, tx)
+	if err != nil {
+		s.Log.Error("Error running final SQL: %v", err)
+		tx.Rollback(context.Background())
+		if ctx.Err() != nil {
+			s.abortIngestion(pool)
+		}
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		s.Log.Error("Error committing final SQL: %v", err)
+		return err
+	}
 
-		DO $$ BEGIN
-		 IF NOT EXISTS (
-		 SELECT 1 FROM pg_constraint WHERE conname = 'unique_trade_constraint'
-		 AND conrelid = 'tradings'::regclass) THEN
-		 ALTER TABLE tradings ADD CONSTRAINT unique_trade_constraint UNIQUE (
-		 data_negocio, codigo_instrumento, hora_fechamento, codigo_identificador_negocio);
-		 END IF;
-		END$$;
-		CREATE INDEX IF NOT EXISTS idx_tradings_ticker_data ON tradings (codigo_instrumento, data_negocio);`
+	bars, err := s.bars.MaterializeDates(ctx, s.DB, dates)
+	if err != nil {
+		s.Log.Error("Error materializing daily bars: %v", err)
+		return err
+	}
+	s.Log.Info("Materialized %d daily bars for %d dates", bars, len(dates))
 
-	if _, err := pool.Exec(context.Background(), finalSQL); err != nil {
-		s.Log.Error("Error running final SQL: %v", err)
+	refreshed, err := s.instruments.RefreshDates(ctx, s.DB, dates)
+	if err != nil {
+		s.Log.Error("Error refreshing instruments catalog: %v", err)
+		return err
+	}
+	s.Log.Info("Refreshed %d instruments", refreshed)
+
+	classified, err := s.classifyInstruments(ctx)
+	if err != nil {
+		s.Log.Error("Error classifying instruments: %v", err)
 		return err
 	}
+	s.Log.Info("Classified %d instruments", classified)
+
+	if s.BlockTrades.Enabled() {
+		flagged, err := s.blocks.DetectDates(ctx, s.DB, dates, s.BlockTrades)
+		if err != nil {
+			s.Log.Error("Error detecting block trades: %v", err)
+			return err
+		}
+		s.Log.Info("Flagged %d block trades", flagged)
+	}
 
 	s.Log.Info("Ingestion finished.")
 	return firstErr
 }
+
+// classifyInstruments stores the classification of every catalog entry that has none yet, which
+// covers the tickers first seen in this run and, after the migration, the whole catalog.
+func (s *Service) classifyInstruments(ctx context.Context) (int, error) {
+	tickers, err := s.instruments.ListUnclassified(ctx, s.DB)
+	if err != nil {
+		return 0, err
+	}
+	classes := make([]classifier.Classification, 0, len(tickers))
+	for _, ticker := range tickers {
+		classes = append(classes, classifier.Classify(ticker))
+	}
+	return len(classes), s.instruments.SaveClassifications(ctx, s.DB, classes)
+}
+
+// participantCode returns the participant code in column i of record, or nil when the column is
+// missing or empty, as in files published before B3 added the buyer and seller columns.
+func participantCode(record []string, i int) any {
+	if i >= len(record) {
+		return nil
+	}
+	code, err := strconv.ParseInt(strings.TrimSpace(record[i]), 10, 32)
+	if err != nil {
+		return nil
+	}
+	return int32(code)
+}
+
+// mergeStagedPartitions merges the staged rows into every partition they touch and empties the
+// staging table. It returns the trading dates that were loaded.
+func (s *Service) mergeStagedPartitions(ctx context.Context, tx pgx.Tx) ([]time.Time, error) {
+	dates, err := queryDates(ctx, tx, `SELECT DISTINCT data_negocio FROM tradings_unlogged WHERE data_negocio IS NOT NULL ORDER BY 1`)
+	if err != nil {
+		return nil, err
+	}
+	attached, err := attachedPartitions(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := partitionsFor(dates, s.granularity(), attached)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON tradings_unlogged (%s)`, stagingKeyIndex, tradeKey)); err != nil {
+		return nil, err
+	}
+	for _, p := range parts {
+		if err := s.mergePartition(ctx, tx, p); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DROP INDEX %s`, stagingKeyIndex)); err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx, `TRUNCATE tradings_unlogged;`)
+	return dates, err
+}
+
+// abortIngestion discards the rows staged by a cancelled or failed run so the next run starts clean.
+// It uses its own context because the ingestion context may already be done.
+func (s *Service) abortIngestion(pool stagingDB) {
+	s.Log.Warning("Discarding staged rows...")
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	if _, err := pool.Exec(ctx, `TRUNCATE tradings_unlogged;`); err != nil {
+		s.Log.Error("Error discarding staged rows: %v", err)
+	}
+}
@@ -1,15 +1,73 @@
 package ingestion
 
 import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/infra/repositories/instruments"
+	"b3-ingest/internal/infra/settings"
 	"b3-ingest/internal/logger"
+	"context"
+	"errors"
 	"io"
+	"os"
+	"path/filepath"
 
 	"testing"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
 
+type mockInstrumentRepository struct {
+	instruments.InstrumentRepository
+	unclassified []string
+	saved        []classifier.Classification
+}
+
+func (m *mockInstrumentRepository) ListUnclassified(ctx context.Context, db *gorm.DB) ([]string, error) {
+	return m.unclassified, nil
+}
+
+func (m *mockInstrumentRepository) SaveClassifications(ctx context.Context, db *gorm.DB, classes []classifier.Classification) error {
+	m.saved = classes
+	return nil
+}
+
+// fakeStagingDB records the statements run against the staging table. CopyFrom reads one row and
+// then cancels the ingestion, as a signal arriving halfway through a file would.
+type fakeStagingDB struct {
+	cancel context.CancelFunc
+	execs  []string
+	read   int
+	begun  bool
+}
+
+func (f *fakeStagingDB) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	f.execs = append(f.execs, sql)
+	return pgconn.CommandTag{}, ctx.Err()
+}
+
+func (f *fakeStagingDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	for rowSrc.Next() {
+		if _, err := rowSrc.Values(); err != nil {
+			return 0, err
+		}
+		f.read++
+		f.cancel()
+		// PostgreSQL keeps none of the rows of a COPY aborted halfway.
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+	}
+	return int64(f.read), rowSrc.Err()
+}
+
+func (f *fakeStagingDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	f.begun = true
+	return nil, errors.New("unexpected merge")
+}
+
 func TestIngestFromCSVGivenNonExistentDirWhenCalledThenReturnsError(t *testing.T) {
 	// Arrange
 	testLogger := logger.NewLogger(io.Discard, "", 0, logger.INFO)
@@ -17,8 +75,66 @@ func TestIngestFromCSVGivenNonExistentDirWhenCalledThenReturnsError(t *testing.T
 	dir := "./nonexistent_dir"
 
 	// Act
-	err := s.IngestFromCSV(dir)
+	_, err := s.IngestFromCSV(context.Background(), dir)
 
 	// Assert
 	assert.Error(t, err)
 }
+
+func TestLoadFilesGivenContextCancelledMidFileWhenLoadedThenDiscardsStagedRowsWithoutMerging(t *testing.T) {
+	// Arrange
+	assert.NoError(t, settings.LoadEnvs())
+	dir := t.TempDir()
+	rows := "DataReferencia;CodigoInstrumento;AcaoAtualizacao;PrecoNegocio;QuantidadeNegociada;HoraFechamento;CodigoIdentificadorNegocio;TipoSessaoPregao;DataNegocio;CodigoParticipanteComprador;CodigoParticipanteVendedor\n" +
+		"2025-07-29;PETR4;0;32,10;100;100000000;1;1;2025-07-29;8;3\n" +
+		"2025-07-29;PETR4;0;32,20;200;100000001;2;1;2025-07-29;8;3\n" +
+		"2025-07-29;PETR4;0;32,30;300;100000002;3;1;2025-07-29;8;3\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "29-07-2025_NEGOCIOSAVISTA.txt"), []byte(rows), 0o644))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := &fakeStagingDB{cancel: cancel}
+	s := &Service{Log: logger.NewLogger(io.Discard, "", 0, logger.INFO)}
+
+	// Act
+	report, err := s.loadFiles(ctx, dir, db)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, report.Aborted)
+	assert.Equal(t, 1, report.FilesFailed)
+	assert.Equal(t, int64(0), report.RowsCopied)
+	assert.Equal(t, 1, db.read, "the copy stops at the cancellation")
+	assert.Equal(t, []string{"TRUNCATE tradings_unlogged;", "TRUNCATE tradings_unlogged;"}, db.execs, "staging is truncated before the load and after the abort")
+	assert.False(t, db.begun, "nothing is merged into tradings")
+}
+
+func TestClassifyInstrumentsGivenUnclassifiedTickersWhenCalledThenSavesTheirClassification(t *testing.T) {
+	// Arrange
+	repo := &mockInstrumentRepository{unclassified: []string{"PETR4", "WDOQ25"}}
+	s := &Service{DB: &gorm.DB{}, Log: logger.NewLogger(io.Discard, "", 0, logger.INFO), instruments: repo}
+
+	// Act
+	n, err := s.classifyInstruments(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, classifier.AssetEquity, repo.saved[0].AssetClass)
+	assert.Equal(t, classifier.AssetFuture, repo.saved[1].AssetClass)
+	assert.Equal(t, "WDOQ25", repo.saved[1].Ticker)
+}
+
+func TestParticipantCodeGivenRecordWhenCalledThenReturnsCodeOrNil(t *testing.T) {
+	// Arrange
+	record := []string{"2025-07-29", "PETR4", "0", "32,10", "100", "100000000", "10", "1", "2025-07-29", "8", ""}
+
+	// Act
+	buyer := participantCode(record, buyerColumn)
+	seller := participantCode(record, sellerColumn)
+	missing := participantCode(record[:9], buyerColumn)
+
+	// Assert
+	assert.Equal(t, int32(8), buyer)
+	assert.Nil(t, seller)
+	assert.Nil(t, missing)
+}
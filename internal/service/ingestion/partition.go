@@ -0,0 +1,208 @@
+package ingestion
+
+import (
+	dbpartition "b3-ingest/internal/infra/adapter/database/partition"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Granularity is the size of each range partition of the tradings table.
+type Granularity string
+
+const (
+	Monthly Granularity = "monthly"
+	Daily   Granularity = "daily"
+)
+
+// ParseGranularity validates a granularity read from the environment. An empty value means Monthly.
+func ParseGranularity(value string) (Granularity, error) {
+	switch g := Granularity(strings.ToLower(strings.TrimSpace(value))); g {
+	case "":
+		return Monthly, nil
+	case Monthly, Daily:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid partition granularity %q, use monthly or daily", value)
+	}
+}
+
+// partition is a single range partition of tradings covering [from, to).
+type partition struct {
+	name string
+	from time.Time
+	to   time.Time
+}
+
+// partitionFor returns the partition that holds rows traded on date.
+func partitionFor(date time.Time, g Granularity) partition {
+	y, m, d := date.Date()
+	if g == Daily {
+		from := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return partition{name: fmt.Sprintf("tradings_p%04d_%02d_%02d", y, m, d), from: from, to: from.AddDate(0, 0, 1)}
+	}
+	from := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	return partition{name: fmt.Sprintf("tradings_p%04d_%02d", y, m), from: from, to: from.AddDate(0, 1, 0)}
+}
+
+// partitionsFor groups dates by partition, keeping the order in which partitions first appear.
+// A date already covered by an attached partition uses that partition whatever its size, so the
+// monthly partitions converted by the first migration keep working under daily granularity. A new
+// partition that would overlap an attached one is refused.
+func partitionsFor(dates []time.Time, g Granularity, attached []partition) ([]partition, error) {
+	var parts []partition
+	seen := map[string]bool{}
+	for _, d := range dates {
+		p, ok := coveringPartition(d, attached)
+		if !ok {
+			p = partitionFor(d, g)
+			for _, a := range attached {
+				if a.from.Before(p.to) && p.from.Before(a.to) {
+					return nil, fmt.Errorf("new %s partition %s overlaps the attached partition %s (%s to %s), PARTITION_GRANULARITY does not match the existing partitions",
+						g, p.name, a.name, a.from.Format("2006-01-02"), a.to.Format("2006-01-02"))
+				}
+			}
+		}
+		if seen[p.name] {
+			continue
+		}
+		seen[p.name] = true
+		parts = append(parts, p)
+	}
+	return parts, nil
+}
+
+func coveringPartition(date time.Time, attached []partition) (partition, bool) {
+	for _, a := range attached {
+		if !date.Before(a.from) && date.Before(a.to) {
+			return a, true
+		}
+	}
+	return partition{}, false
+}
+
+// attachedPartitions lists the partitions of tradings with their real bounds.
+func attachedPartitions(ctx context.Context, tx pgx.Tx) ([]partition, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'tradings'::regclass`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var parts []partition
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, err
+		}
+		p, err := parsePartition(name, bound)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+	}
+	return parts, rows.Err()
+}
+
+// parsePartition reads the range of a partition from its pg_get_expr(relpartbound) text.
+func parsePartition(name, bound string) (partition, error) {
+	from, to, err := dbpartition.ParseBound(bound)
+	if err != nil {
+		return partition{}, fmt.Errorf("partition %s: %w", name, err)
+	}
+	return partition{name: name, from: from, to: to}, nil
+}
+
+func (s *Service) granularity() Granularity {
+	if s.Granularity == "" {
+		return Monthly
+	}
+	return s.Granularity
+}
+
+// tradeKey is the key of unique_trade_constraint. A staged trade replaces the stored trade with
+// the same key and every other stored row is kept.
+const tradeKey = "data_negocio, codigo_instrumento, hora_fechamento, codigo_identificador_negocio"
+
+const tradingColumns = `data_negocio, codigo_instrumento, preco_negocio, quantidade_negociada, hora_fechamento, codigo_identificador_negocio,
+	codigo_participante_comprador, codigo_participante_vendedor`
+
+// stagingKeyIndex indexes the trade key of the staged rows for the merge. It is created inside the
+// final transaction and dropped before the staging table is emptied, so COPY never maintains it.
+const stagingKeyIndex = "tradings_unlogged_trade_key"
+
+// mergeStatements merges the staged trades between from and to into the partition name. When the
+// partition exists, the stored trades staged again are deleted first; every other stored row is
+// left untouched. One copy of each staged trade is then inserted.
+func mergeStatements(name, from, to string, exists bool) []string {
+	var stmts []string
+	if exists {
+		stmts = append(stmts, fmt.Sprintf(`DELETE FROM %s AS t
+			WHERE EXISTS (
+				SELECT 1 FROM tradings_unlogged u
+				WHERE u.data_negocio = t.data_negocio AND u.codigo_instrumento = t.codigo_instrumento
+					AND u.hora_fechamento = t.hora_fechamento AND u.codigo_identificador_negocio = t.codigo_identificador_negocio
+			)`, name))
+	}
+	stmts = append(stmts, fmt.Sprintf(`INSERT INTO %[1]s (%[2]s)
+		SELECT %[2]s FROM (
+			SELECT %[2]s, ROW_NUMBER() OVER (PARTITION BY %[3]s) AS rn
+			FROM tradings_unlogged WHERE data_negocio >= '%[4]s' AND data_negocio < '%[5]s'
+		) staged
+		WHERE rn = 1`, name, tradingColumns, tradeKey, from, to))
+	return stmts
+}
+
+// mergePartition merges the staged rows of p into it. An attached partition is changed in place,
+// only for the staged trade keys, so loading one day into a monthly partition does not rewrite the
+// month and readers keep using the partition until the transaction commits. A new partition is
+// filled while still detached and attached with a CHECK constraint matching its bounds, which lets
+// ATTACH skip the validation scan and only take a SHARE UPDATE EXCLUSIVE lock on tradings.
+func (s *Service) mergePartition(ctx context.Context, tx pgx.Tx, p partition) error {
+	name := pgx.Identifier{p.name}.Sanitize()
+	bounds := pgx.Identifier{p.name + "_bounds"}.Sanitize()
+	from, to := p.from.Format("2006-01-02"), p.to.Format("2006-01-02")
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, p.name).Scan(&exists); err != nil {
+		return err
+	}
+
+	var stmts []string
+	if !exists {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (LIKE tradings INCLUDING DEFAULTS)`, name))
+	}
+	stmts = append(stmts, mergeStatements(name, from, to, exists)...)
+	if !exists {
+		stmts = append(stmts,
+			fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s CHECK (data_negocio IS NOT NULL AND data_negocio >= '%s' AND data_negocio < '%s')`, name, bounds, from, to),
+			fmt.Sprintf(`ALTER TABLE tradings ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')`, name, from, to),
+			fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT %s`, name, bounds))
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("merging partition %s: %w", p.name, err)
+		}
+	}
+	if exists {
+		s.Log.Info("Merged staged trades into partition %s", p.name)
+	} else {
+		s.Log.Info("Created partition %s", p.name)
+	}
+	return nil
+}
+
+func queryDates(ctx context.Context, tx pgx.Tx, sql string) ([]time.Time, error) {
+	rows, err := tx.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[time.Time])
+}
@@ -0,0 +1,168 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPartitionForGivenMonthlyGranularityWhenCalledThenCoversWholeMonth(t *testing.T) {
+	// Arrange
+	date := time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	p := partitionFor(date, Monthly)
+
+	// Assert
+	assert.Equal(t, "tradings_p2025_12", p.name)
+	assert.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), p.from)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), p.to)
+}
+
+func TestPartitionForGivenDailyGranularityWhenCalledThenCoversSingleDay(t *testing.T) {
+	// Arrange
+	date := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	p := partitionFor(date, Daily)
+
+	// Assert
+	assert.Equal(t, "tradings_p2025_07_31", p.name)
+	assert.Equal(t, time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), p.from)
+	assert.Equal(t, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), p.to)
+}
+
+func TestPartitionsForGivenDatesInSameMonthWhenGroupedThenReturnsOnePartitionPerMonth(t *testing.T) {
+	// Arrange
+	dates := []time.Time{
+		time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	parts, err := partitionsFor(dates, Monthly, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, parts, 2)
+	assert.Equal(t, "tradings_p2025_07", parts[0].name)
+	assert.Equal(t, "tradings_p2025_08", parts[1].name)
+}
+
+func TestPartitionsForGivenMonthlyPartitionAttachedWhenDailyThenReusesIt(t *testing.T) {
+	// Arrange
+	attached := []partition{partitionFor(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Monthly)}
+	dates := []time.Time{
+		time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Act
+	parts, err := partitionsFor(dates, Daily, attached)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, parts, 2)
+	assert.Equal(t, attached[0], parts[0])
+	assert.Equal(t, "tradings_p2025_08_01", parts[1].name)
+}
+
+func TestPartitionsForGivenDailyPartitionsAttachedWhenMonthlyThenRefusesOverlap(t *testing.T) {
+	// Arrange
+	attached := []partition{partitionFor(time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), Daily)}
+	dates := []time.Time{time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)}
+
+	// Act
+	_, err := partitionsFor(dates, Monthly, attached)
+
+	// Assert
+	assert.ErrorContains(t, err, "tradings_p2025_07_30")
+}
+
+func TestParsePartitionGivenBoundWhenParsedThenReturnsRange(t *testing.T) {
+	// Act
+	p, err := parsePartition("tradings_p2025_07", "FOR VALUES FROM ('2025-07-01') TO ('2025-08-01')")
+	_, errInvalid := parsePartition("tradings_default", "DEFAULT")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, partitionFor(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Monthly), p)
+	assert.Error(t, errInvalid)
+}
+
+func TestParseGranularityGivenValuesWhenParsedThenValidates(t *testing.T) {
+	// Act
+	empty, errEmpty := ParseGranularity("")
+	daily, errDaily := ParseGranularity("Daily")
+	_, errInvalid := ParseGranularity("weekly")
+
+	// Assert
+	assert.NoError(t, errEmpty)
+	assert.Equal(t, Monthly, empty)
+	assert.NoError(t, errDaily)
+	assert.Equal(t, Daily, daily)
+	assert.Error(t, errInvalid)
+}
+
+type stagedTrading struct {
+	DataNegocio                 time.Time
+	CodigoInstrumento           string
+	PrecoNegocio                float64
+	QuantidadeNegociada         int64
+	HoraFechamento              int64
+	CodigoIdentificadorNegocio  int64
+	CodigoParticipanteComprador *int64
+	CodigoParticipanteVendedor  *int64
+}
+
+// loadFile stages trades and merges them into the July 2025 partition the way mergePartition does.
+func loadFile(t *testing.T, db *gorm.DB, trades []stagedTrading) {
+	assert.NoError(t, db.Table("tradings_unlogged").Create(&trades).Error)
+	exists := db.Migrator().HasTable("tradings_p2025_07")
+	if !exists {
+		assert.NoError(t, db.Table("tradings_p2025_07").AutoMigrate(&stagedTrading{}))
+	}
+	for _, stmt := range mergeStatements("tradings_p2025_07", "2025-07-01", "2025-08-01", exists) {
+		assert.NoError(t, db.Exec(stmt).Error)
+	}
+	assert.NoError(t, db.Exec("DELETE FROM tradings_unlogged").Error)
+}
+
+func TestMergeStatementsGivenTwoFilesForSameDateWhenLoadedThenKeepsTradesOfBoth(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Table("tradings_unlogged").AutoMigrate(&stagedTrading{}))
+	date := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+	first := []stagedTrading{
+		{DataNegocio: date, CodigoInstrumento: "PETR4", PrecoNegocio: 30, QuantidadeNegociada: 100, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 1},
+		{DataNegocio: date, CodigoInstrumento: "PETR4", PrecoNegocio: 31, QuantidadeNegociada: 100, HoraFechamento: 100100000, CodigoIdentificadorNegocio: 2},
+	}
+	second := []stagedTrading{
+		{DataNegocio: date, CodigoInstrumento: "VALE3", PrecoNegocio: 60, QuantidadeNegociada: 200, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 1},
+		{DataNegocio: date, CodigoInstrumento: "VALE3", PrecoNegocio: 60, QuantidadeNegociada: 200, HoraFechamento: 100000000, CodigoIdentificadorNegocio: 1},
+	}
+
+	// Act
+	loadFile(t, db, first)
+	loadFile(t, db, second)
+	loadFile(t, db, first)
+
+	// Assert
+	var counts []struct {
+		CodigoInstrumento string
+		Total             int64
+	}
+	assert.NoError(t, db.Raw(`SELECT codigo_instrumento, COUNT(*) AS total FROM tradings_p2025_07
+		GROUP BY codigo_instrumento ORDER BY codigo_instrumento`).Scan(&counts).Error)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, "PETR4", counts[0].CodigoInstrumento)
+	assert.Equal(t, int64(2), counts[0].Total)
+	assert.Equal(t, "VALE3", counts[1].CodigoInstrumento)
+	assert.Equal(t, int64(1), counts[1].Total)
+}
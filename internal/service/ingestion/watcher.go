@@ -0,0 +1,249 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	doneDir   = "done"
+	failedDir = "failed"
+)
+
+// WatchOptions controls how often the watched directory is scanned and how long
+// a file must stay unchanged before it is considered completely written.
+type WatchOptions struct {
+	PollInterval time.Duration
+	SettleDelay  time.Duration
+}
+
+func (o WatchOptions) Validate() error {
+	if o.PollInterval <= 0 {
+		return errors.New("watch poll interval must be greater than zero")
+	}
+	if o.SettleDelay < 0 {
+		return errors.New("watch settle delay must not be negative")
+	}
+	return nil
+}
+
+// fileState is the last observed size and modification time of a watched file.
+type fileState struct {
+	size      int64
+	modTime   time.Time
+	changedAt time.Time
+}
+
+// settleTracker remembers the files seen in the watched directory and reports
+// the ones whose size and modification time did not change for the settle delay.
+type settleTracker struct {
+	delay time.Duration
+	files map[string]fileState
+	seen  map[string]bool
+}
+
+func newSettleTracker(delay time.Duration) *settleTracker {
+	return &settleTracker{delay: delay, files: map[string]fileState{}, seen: map[string]bool{}}
+}
+
+// observe records the current state of the directory and returns the files that are ready to be ingested.
+// A file is returned at most once.
+func (t *settleTracker) observe(infos []os.FileInfo, now time.Time) []string {
+	var ready []string
+	present := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		present[name] = true
+		if t.seen[name] {
+			continue
+		}
+		prev, ok := t.files[name]
+		if !ok || prev.size != info.Size() || !prev.modTime.Equal(info.ModTime()) {
+			t.files[name] = fileState{size: info.Size(), modTime: info.ModTime(), changedAt: now}
+			continue
+		}
+		if now.Sub(prev.changedAt) >= t.delay {
+			ready = append(ready, name)
+			t.seen[name] = true
+			delete(t.files, name)
+		}
+	}
+	for name := range t.files {
+		if !present[name] {
+			delete(t.files, name)
+		}
+	}
+	for name := range t.seen {
+		if !present[name] {
+			delete(t.seen, name)
+		}
+	}
+	return ready
+}
+
+// isWatchCandidate reports whether a file in the watched directory should be ingested.
+// Hidden and partial download files are ignored.
+func isWatchCandidate(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".txt", ".zip":
+		return true
+	}
+	return false
+}
+
+// Watch monitors dir for new CSV and zip files and ingests each one once it has settled.
+// Ingested files are moved to the done/ subfolder, files that failed to load to failed/.
+// It runs until ctx is cancelled.
+func (s *Service) Watch(ctx context.Context, dir string, opts WatchOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	s.Log.Info("Watching %s for new files (poll=%s, settle=%s)...", dir, opts.PollInterval, opts.SettleDelay)
+	for _, sub := range []string{doneDir, failedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return err
+		}
+	}
+	pool, err := pgxpool.New(ctx, s.DSN)
+	if err != nil {
+		s.Log.Error("Error connecting to database: %v", err)
+		return err
+	}
+	defer pool.Close()
+
+	tracker := newSettleTracker(opts.SettleDelay)
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		infos, err := listWatchCandidates(dir)
+		if err != nil {
+			s.Log.Error("Error listing files in directory %s: %v", dir, err)
+		}
+		for _, name := range tracker.observe(infos, time.Now()) {
+			if err := s.ingestWatchedFile(ctx, pool, dir, name); err != nil && ctx.Err() != nil {
+				s.Log.Warning("Watch stopped while ingesting %s, file left in place", name)
+				return ctx.Err()
+			}
+		}
+		select {
+		case <-ctx.Done():
+			s.Log.Info("Watch stopped.")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func listWatchCandidates(dir string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var infos []os.FileInfo
+	for _, e := range entries {
+		if e.IsDir() || !isWatchCandidate(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// ingestWatchedFile loads a single file through processFile and moves it to done/ or failed/.
+// Zip files are extracted to a temporary folder and every file inside is loaded in the same run.
+// Each file is merged into the stored trades, so split or late files for a session add to it.
+func (s *Service) ingestWatchedFile(ctx context.Context, pool *pgxpool.Pool, dir, name string) error {
+	s.Log.Info("Ingesting watched file: %s", name)
+	stats, err := s.loadWatchedFile(ctx, pool, dir, name)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	target := doneDir
+	if err != nil {
+		s.Log.Error("Error ingesting watched file %s: %v", name, err)
+		target = failedDir
+	} else {
+		s.Log.Info("Ingested %s: %d rows, %d filtered", name, stats.copied, stats.filtered)
+	}
+	if mvErr := moveWatchedFile(dir, name, target, time.Now()); mvErr != nil {
+		s.Log.Error("Error moving %s to %s/: %v", name, target, mvErr)
+	}
+	return err
+}
+
+// moveWatchedFile moves name from dir to the target subfolder. A file of the same name already in
+// the subfolder, from an earlier delivery, is kept: the new one gets the time of the move appended.
+func moveWatchedFile(dir, name, target string, now time.Time) error {
+	dest := filepath.Join(dir, target, name)
+	if _, err := os.Lstat(dest); err == nil {
+		ext := filepath.Ext(name)
+		stamped := fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), now.Format("20060102T150405.000000000"), ext)
+		dest = filepath.Join(dir, target, stamped)
+		if _, err := os.Lstat(dest); err == nil {
+			return fmt.Errorf("%s already exists", dest)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Rename(filepath.Join(dir, name), dest)
+}
+
+func (s *Service) loadWatchedFile(ctx context.Context, pool *pgxpool.Pool, dir, name string) (fileStats, error) {
+	var total fileStats
+	unlock, err := s.lockStaging(ctx, pool)
+	if err != nil {
+		return total, err
+	}
+	defer unlock()
+	if err := s.prepareDatabase(ctx, pool); err != nil {
+		return total, err
+	}
+
+	srcDir, files := dir, []string{name}
+	if strings.EqualFold(filepath.Ext(name), ".zip") {
+		tmpDir, err := os.MkdirTemp(dir, ".extract-")
+		if err != nil {
+			return total, err
+		}
+		defer os.RemoveAll(tmpDir)
+		if err := unzip(filepath.Join(dir, name), tmpDir, s.Log.Debug); err != nil {
+			return total, err
+		}
+		entries, err := os.ReadDir(tmpDir)
+		if err != nil {
+			return total, err
+		}
+		srcDir, files = tmpDir, nil
+		for _, e := range entries {
+			if !e.IsDir() {
+				files = append(files, e.Name())
+			}
+		}
+	}
+
+	for _, f := range files {
+		stats, err := s.processFile(ctx, f, srcDir, pool)
+		if err != nil {
+			s.abortIngestion(pool)
+			return total, err
+		}
+		total.copied += stats.copied
+		total.filtered += stats.filtered
+	}
+	return total, s.finalizeIngestion(ctx, pool, nil)
+}
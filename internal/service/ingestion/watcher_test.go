@@ -0,0 +1,107 @@
+package ingestion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFileInfo struct {
+	os.FileInfo
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (f fakeFileInfo) Name() string       { return f.name }
+func (f fakeFileInfo) Size() int64        { return f.size }
+func (f fakeFileInfo) ModTime() time.Time { return f.modTime }
+
+func TestSettleTrackerGivenGrowingFileWhenObservedThenWaitsUntilSettled(t *testing.T) {
+	// Arrange
+	tracker := newSettleTracker(10 * time.Second)
+	now := time.Date(2025, 7, 29, 10, 0, 0, 0, time.UTC)
+	mod := now.Add(-time.Second)
+
+	// Act
+	first := tracker.observe([]os.FileInfo{fakeFileInfo{name: "a.csv", size: 10, modTime: mod}}, now)
+	grown := tracker.observe([]os.FileInfo{fakeFileInfo{name: "a.csv", size: 20, modTime: now}}, now.Add(5*time.Second))
+	early := tracker.observe([]os.FileInfo{fakeFileInfo{name: "a.csv", size: 20, modTime: now}}, now.Add(10*time.Second))
+	settled := tracker.observe([]os.FileInfo{fakeFileInfo{name: "a.csv", size: 20, modTime: now}}, now.Add(15*time.Second))
+	again := tracker.observe([]os.FileInfo{fakeFileInfo{name: "a.csv", size: 20, modTime: now}}, now.Add(30*time.Second))
+
+	// Assert
+	assert.Empty(t, first)
+	assert.Empty(t, grown)
+	assert.Empty(t, early)
+	assert.Equal(t, []string{"a.csv"}, settled)
+	assert.Empty(t, again)
+}
+
+func TestSettleTrackerGivenRemovedFileWhenReappearsThenIsTrackedAgain(t *testing.T) {
+	// Arrange
+	tracker := newSettleTracker(0)
+	now := time.Date(2025, 7, 29, 10, 0, 0, 0, time.UTC)
+	info := fakeFileInfo{name: "a.zip", size: 10, modTime: now}
+	tracker.observe([]os.FileInfo{info}, now)
+	tracker.observe([]os.FileInfo{info}, now)
+
+	// Act
+	tracker.observe(nil, now)
+	tracker.observe([]os.FileInfo{info}, now)
+	ready := tracker.observe([]os.FileInfo{info}, now)
+
+	// Assert
+	assert.Equal(t, []string{"a.zip"}, ready)
+}
+
+func TestIsWatchCandidateGivenFileNamesWhenCheckedThenFiltersByExtension(t *testing.T) {
+	// Arrange
+	cases := map[string]bool{
+		"29-07-2025_NEGOCIOSAVISTA.txt": true,
+		"2025-07-29.zip":                true,
+		"trades.CSV":                    true,
+		".hidden.csv":                   false,
+		"2025-07-29.zip.part":           false,
+		"notes.md":                      false,
+	}
+
+	for name, expected := range cases {
+		// Act
+		got := isWatchCandidate(name)
+
+		// Assert
+		assert.Equal(t, expected, got, name)
+	}
+}
+
+func TestWatchOptionsValidateGivenIntervalsWhenValidatedThenRejectsNonPositivePoll(t *testing.T) {
+	assert.NoError(t, WatchOptions{PollInterval: 2 * time.Second, SettleDelay: 10 * time.Second}.Validate())
+	assert.NoError(t, WatchOptions{PollInterval: time.Second}.Validate())
+	assert.Error(t, WatchOptions{PollInterval: 0, SettleDelay: time.Second}.Validate())
+	assert.Error(t, WatchOptions{PollInterval: -time.Second}.Validate())
+	assert.Error(t, WatchOptions{PollInterval: time.Second, SettleDelay: -time.Second}.Validate())
+}
+
+func TestMoveWatchedFileGivenSameNameAlreadyMovedWhenMovedThenKeepsBoth(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, doneDir), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, doneDir, "trades.csv"), []byte("first"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "trades.csv"), []byte("second"), 0644))
+	now := time.Date(2025, 7, 29, 18, 30, 0, 0, time.UTC)
+
+	// Act
+	err := moveWatchedFile(dir, "trades.csv", doneDir, now)
+
+	// Assert
+	assert.NoError(t, err)
+	first, _ := os.ReadFile(filepath.Join(dir, doneDir, "trades.csv"))
+	second, _ := os.ReadFile(filepath.Join(dir, doneDir, "trades.20250729T183000.000000000.csv"))
+	assert.Equal(t, "first", string(first))
+	assert.Equal(t, "second", string(second))
+	assert.NoFileExists(t, filepath.Join(dir, "trades.csv"))
+}
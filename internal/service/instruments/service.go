@@ -0,0 +1,26 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/instruments"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type InstrumentService interface {
+	ListInstruments(ctx context.Context, filter models.InstrumentFilter) ([]models.Instrument, int64, error)
+}
+
+type instrumentService struct {
+	repo instruments.InstrumentRepository
+	db   *gorm.DB
+}
+
+func NewInstrumentService(repo instruments.InstrumentRepository, db *gorm.DB) InstrumentService {
+	return &instrumentService{repo: repo, db: db}
+}
+
+func (s *instrumentService) ListInstruments(ctx context.Context, filter models.InstrumentFilter) ([]models.Instrument, int64, error) {
+	return s.repo.List(ctx, s.db, filter)
+}
@@ -0,0 +1,37 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/instruments"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockInstrumentRepository struct {
+	instruments.InstrumentRepository
+	gotFilter models.InstrumentFilter
+}
+
+func (m *mockInstrumentRepository) List(ctx context.Context, db *gorm.DB, filter models.InstrumentFilter) ([]models.Instrument, int64, error) {
+	m.gotFilter = filter
+	return []models.Instrument{{Ticker: "PETR4"}}, 7, nil
+}
+
+func TestListInstrumentsGivenFilterWhenCalledThenDelegatesToRepository(t *testing.T) {
+	// Arrange
+	repo := &mockInstrumentRepository{}
+	svc := NewInstrumentService(repo, nil)
+	filter := models.InstrumentFilter{Prefix: "PETR", Limit: 10}
+
+	// Act
+	page, total, err := svc.ListInstruments(context.Background(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, filter, repo.gotFilter)
+	assert.Len(t, page, 1)
+	assert.Equal(t, int64(7), total)
+}
@@ -0,0 +1,54 @@
+package quality
+
+import (
+	"b3-ingest/internal/domain/quality"
+	qualityRepo "b3-ingest/internal/infra/repositories/quality"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxReportedIssues caps the price jumps and duplicate trades listed in a report.
+const maxReportedIssues = 1000
+
+// duplicateScanDays is how many calendar days, ending at the end of the range, are scanned for
+// duplicate trades. It is the only check reading the raw trades.
+const duplicateScanDays = 31
+
+type QualityService interface {
+	Check(ctx context.Context, startDate, endDate time.Time) (quality.Report, error)
+}
+
+type qualityService struct {
+	repo qualityRepo.QualityRepository
+	db   *gorm.DB
+	opts quality.Options
+}
+
+func NewQualityService(repo qualityRepo.QualityRepository, db *gorm.DB, opts quality.Options) QualityService {
+	return &qualityService{repo: repo, db: db, opts: opts}
+}
+
+// Check runs every data-quality check over the sessions between startDate and endDate.
+func (s *qualityService) Check(ctx context.Context, startDate, endDate time.Time) (quality.Report, error) {
+	counts, err := s.repo.GetDayCounts(ctx, s.db, quality.LookbackStart(startDate, s.opts), endDate)
+	if err != nil {
+		return quality.Report{}, err
+	}
+	jumps, err := s.repo.GetPriceJumps(ctx, s.db, startDate, endDate, s.opts.PriceJump, maxReportedIssues)
+	if err != nil {
+		return quality.Report{}, err
+	}
+	duplicatesFrom := endDate.AddDate(0, 0, 1-duplicateScanDays)
+	if duplicatesFrom.Before(startDate) {
+		duplicatesFrom = startDate
+	}
+	duplicates, err := s.repo.GetDuplicateTrades(ctx, s.db, duplicatesFrom, endDate, maxReportedIssues)
+	if err != nil {
+		return quality.Report{}, err
+	}
+	report := quality.Check(startDate, endDate, s.opts, counts, jumps, duplicates)
+	report.DuplicatesFrom = duplicatesFrom
+	return report, nil
+}
@@ -0,0 +1,73 @@
+package quality
+
+import (
+	"b3-ingest/internal/domain/quality"
+	qualityRepo "b3-ingest/internal/infra/repositories/quality"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockQualityRepository struct {
+	qualityRepo.QualityRepository
+	counts       []quality.DayCount
+	gotStart     time.Time
+	gotThreshold float64
+	gotDupStart  time.Time
+}
+
+func (m *mockQualityRepository) GetDayCounts(ctx context.Context, db *gorm.DB, startDate, endDate time.Time) ([]quality.DayCount, error) {
+	m.gotStart = startDate
+	return m.counts, nil
+}
+
+func (m *mockQualityRepository) GetPriceJumps(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, threshold float64, limit int) ([]quality.PriceJump, error) {
+	m.gotThreshold = threshold
+	return []quality.PriceJump{{Ticker: "PETR4", Date: endDate, PreviousClose: 32, Close: 16, Change: -0.5}}, nil
+}
+
+func (m *mockQualityRepository) GetDuplicateTrades(ctx context.Context, db *gorm.DB, startDate, endDate time.Time, limit int) ([]quality.DuplicateTrade, error) {
+	m.gotDupStart = startDate
+	return nil, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, 7, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCheckGivenLoadedDaysWhenCalledThenReadsTrailingSessionsAndReportsIssues(t *testing.T) {
+	// Arrange
+	repo := &mockQualityRepository{counts: []quality.DayCount{{Date: day(28), Rows: 100}}}
+	opts := quality.Options{TrailingDays: 5, RowDeviation: 0.5, PriceJump: 0.3}
+	svc := NewQualityService(repo, nil, opts)
+
+	// Act
+	report, err := svc.Check(context.Background(), day(28), day(29))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, day(21), repo.gotStart)
+	assert.Equal(t, 0.3, repo.gotThreshold)
+	assert.Equal(t, []time.Time{day(29)}, report.MissingDates)
+	assert.Len(t, report.PriceJumps, 1)
+	assert.Empty(t, report.DuplicateTrades)
+	assert.Equal(t, day(28), report.DuplicatesFrom)
+}
+
+func TestCheckGivenLongRangeWhenCalledThenScansDuplicatesOfLastDaysOnly(t *testing.T) {
+	// Arrange
+	repo := &mockQualityRepository{}
+	opts := quality.Options{TrailingDays: 5, RowDeviation: 0.5, PriceJump: 0.3}
+	svc := NewQualityService(repo, nil, opts)
+
+	// Act
+	report, err := svc.Check(context.Background(), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), day(31))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, day(1), repo.gotDupStart)
+	assert.Equal(t, day(1), report.DuplicatesFrom)
+}
@@ -0,0 +1,216 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"b3-ingest/internal/infra/adapter/database/partition"
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"b3-ingest/internal/infra/repositories/dailybars"
+	"b3-ingest/internal/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRetentionDisabled is returned when a purge is requested without a retention window.
+var ErrRetentionDisabled = errors.New("retention window is not configured, set RETENTION_MONTHS")
+
+// Options controls a purge run.
+type Options struct {
+	// Months is the retention window: trades older than this many months are purged.
+	Months int
+	// KeepAggregates keeps the daily bars of the purged days, storing the missing ones before the
+	// raw trades are removed. Without it the bars before the cutoff are deleted too.
+	KeepAggregates bool
+	// DryRun reports what would be purged without changing anything.
+	DryRun bool
+}
+
+// Report summarizes what a purge removed, or would remove on a dry run.
+type Report struct {
+	Cutoff            time.Time
+	PartitionsDropped []string
+	RowsDeleted       int64
+	BytesReclaimed    int64
+	// BarsKept and BarsDropped count the daily bars before the cutoff that stay or are deleted.
+	// A dry run does not store missing bars, so BarsKept only counts the bars already stored.
+	BarsKept    int64
+	BarsDropped int64
+	// BlockTradesDeleted and MediansDeleted count the block trades and daily median trade sizes of
+	// the purged days, which are removed with the raw trades they were computed from.
+	BlockTradesDeleted int64
+	MediansDeleted     int64
+	DryRun             bool
+}
+
+// partitionInfo is a tradings partition as read from the catalog.
+type partitionInfo struct {
+	Name  string `gorm:"column:name"`
+	Bound string `gorm:"column:bound"`
+	Bytes int64  `gorm:"column:bytes"`
+	from  time.Time
+	to    time.Time
+}
+
+// purgePlan splits the partitions older than the cutoff into the ones that can be dropped
+// whole and the one that straddles the cutoff and needs a row level delete.
+type purgePlan struct {
+	drop    []partitionInfo
+	partial []partitionInfo
+}
+
+type Service struct {
+	DB     *gorm.DB
+	Log    *logger.Logger
+	bars   dailybars.DailyBarRepository
+	blocks blocktrades.BlockTradeRepository
+}
+
+func NewService(db *gorm.DB, log *logger.Logger) *Service {
+	return &Service{DB: db, Log: log, bars: dailybars.NewDailyBarRepository(), blocks: blocktrades.NewBlockTradeRepository()}
+}
+
+// Cutoff returns the first day that is kept for a retention window of months ending at now.
+func Cutoff(now time.Time, months int) time.Time {
+	y, m, d := now.AddDate(0, -months, 0).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func planPurge(partitions []partitionInfo, cutoff time.Time) purgePlan {
+	var plan purgePlan
+	for _, p := range partitions {
+		switch {
+		case !p.to.After(cutoff):
+			plan.drop = append(plan.drop, p)
+		case p.from.Before(cutoff):
+			plan.partial = append(plan.partial, p)
+		}
+	}
+	return plan
+}
+
+func (s *Service) listPartitions(ctx context.Context, db *gorm.DB) ([]partitionInfo, error) {
+	var partitions []partitionInfo
+	err := db.WithContext(ctx).Raw(`
+		SELECT c.relname AS name, pg_get_expr(c.relpartbound, c.oid) AS bound, pg_total_relation_size(c.oid) AS bytes
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'tradings'::regclass
+		ORDER BY c.relname`).Scan(&partitions).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range partitions {
+		from, to, err := partition.ParseBound(partitions[i].Bound)
+		if err != nil {
+			return nil, err
+		}
+		partitions[i].from, partitions[i].to = from, to
+	}
+	return partitions, nil
+}
+
+// planBars keeps or drops the daily bars before cutoff as opts asks and counts them in report.
+func (s *Service) planBars(ctx context.Context, tx *gorm.DB, cutoff time.Time, opts Options, report *Report) error {
+	var err error
+	switch {
+	case opts.KeepAggregates:
+		if !opts.DryRun {
+			if _, err = s.bars.MaterializeBefore(ctx, tx, cutoff); err != nil {
+				return err
+			}
+		}
+		report.BarsKept, err = s.bars.CountBefore(ctx, tx, cutoff)
+	case opts.DryRun:
+		report.BarsDropped, err = s.bars.CountBefore(ctx, tx, cutoff)
+	default:
+		report.BarsDropped, err = s.bars.DeleteBefore(ctx, tx, cutoff)
+	}
+	return err
+}
+
+// planBlockTrades deletes the block trades and daily medians before cutoff, or only counts them on
+// a dry run, and records the counts in report.
+func (s *Service) planBlockTrades(ctx context.Context, tx *gorm.DB, cutoff time.Time, opts Options, report *Report) error {
+	var err error
+	if opts.DryRun {
+		report.BlockTradesDeleted, report.MediansDeleted, err = s.blocks.CountBefore(ctx, tx, cutoff)
+	} else {
+		report.BlockTradesDeleted, report.MediansDeleted, err = s.blocks.DeleteBefore(ctx, tx, cutoff)
+	}
+	return err
+}
+
+// Purge removes trades older than the retention window. Partitions entirely before the cutoff
+// are detached and dropped, the partition that straddles the cutoff is deleted row by row, and
+// the block trades and daily medians of the purged days are deleted with them.
+// Everything runs in one transaction so a failure leaves the data untouched.
+func (s *Service) Purge(ctx context.Context, opts Options) (Report, error) {
+	if opts.Months <= 0 {
+		return Report{}, ErrRetentionDisabled
+	}
+	report := Report{Cutoff: Cutoff(time.Now(), opts.Months), DryRun: opts.DryRun}
+	s.Log.Info("Purging trades before %s (dry run: %t)...", report.Cutoff.Format("2006-01-02"), opts.DryRun)
+
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		partitions, err := s.listPartitions(ctx, tx)
+		if err != nil {
+			return err
+		}
+		plan := planPurge(partitions, report.Cutoff)
+
+		if err := s.planBars(ctx, tx, report.Cutoff, opts, &report); err != nil {
+			return fmt.Errorf("keeping daily aggregates: %w", err)
+		}
+		if err := s.planBlockTrades(ctx, tx, report.Cutoff, opts, &report); err != nil {
+			return fmt.Errorf("purging block trades: %w", err)
+		}
+
+		for _, p := range plan.drop {
+			var rows int64
+			if err := tx.Table(p.Name).Count(&rows).Error; err != nil {
+				return err
+			}
+			report.PartitionsDropped = append(report.PartitionsDropped, p.Name)
+			report.RowsDeleted += rows
+			report.BytesReclaimed += p.Bytes
+			if opts.DryRun {
+				continue
+			}
+			if err := tx.Exec(`ALTER TABLE tradings DETACH PARTITION ?`, clause.Table{Name: p.Name}).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DROP TABLE ?`, clause.Table{Name: p.Name}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, p := range plan.partial {
+			var total, old int64
+			if err := tx.Table(p.Name).Count(&total).Error; err != nil {
+				return err
+			}
+			if err := tx.Table(p.Name).Where("data_negocio < ?", report.Cutoff).Count(&old).Error; err != nil {
+				return err
+			}
+			if !opts.DryRun {
+				if err := tx.Exec(`DELETE FROM ? WHERE data_negocio < ?`, clause.Table{Name: p.Name}, report.Cutoff).Error; err != nil {
+					return err
+				}
+			}
+			report.RowsDeleted += old
+			// Deleted rows are only reusable after VACUUM, so the space is estimated from the row share.
+			if total > 0 {
+				report.BytesReclaimed += p.Bytes * old / total
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Report{}, err
+	}
+	return report, nil
+}
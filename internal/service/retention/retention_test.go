@@ -0,0 +1,143 @@
+package retention
+
+import (
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"b3-ingest/internal/infra/repositories/dailybars"
+	"b3-ingest/internal/logger"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCutoffGivenRetentionMonthsWhenCalledThenReturnsStartOfKeptWindow(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 8, 15, 18, 30, 0, 0, time.UTC)
+
+	// Act
+	cutoff := Cutoff(now, 3)
+
+	// Assert
+	assert.Equal(t, time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC), cutoff)
+}
+
+func TestPlanPurgeGivenPartitionsAroundCutoffWhenPlannedThenSplitsDropAndPartial(t *testing.T) {
+	// Arrange
+	month := func(y int, m time.Month) partitionInfo {
+		from := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return partitionInfo{Name: from.Format("2006_01"), from: from, to: from.AddDate(0, 1, 0)}
+	}
+	partitions := []partitionInfo{month(2025, 4), month(2025, 5), month(2025, 6)}
+	cutoff := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	plan := planPurge(partitions, cutoff)
+
+	// Assert
+	assert.Len(t, plan.drop, 1)
+	assert.Equal(t, "2025_04", plan.drop[0].Name)
+	assert.Len(t, plan.partial, 1)
+	assert.Equal(t, "2025_05", plan.partial[0].Name)
+}
+
+func TestPurgeGivenNoRetentionWindowWhenCalledThenReturnsDisabledError(t *testing.T) {
+	// Arrange
+	s := NewService(&gorm.DB{}, logger.NewLogger(io.Discard, "", 0, logger.INFO))
+
+	// Act
+	_, err := s.Purge(context.Background(), Options{Months: 0})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrRetentionDisabled)
+}
+
+type mockDailyBarRepository struct {
+	dailybars.DailyBarRepository
+	stored       int64
+	materialized bool
+	deleted      bool
+}
+
+func (m *mockDailyBarRepository) MaterializeBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	m.materialized = true
+	m.stored += 2
+	return 2, nil
+}
+
+func (m *mockDailyBarRepository) CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	return m.stored, nil
+}
+
+func (m *mockDailyBarRepository) DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, error) {
+	m.deleted = true
+	return m.stored, nil
+}
+
+func TestPlanBarsGivenOptionsWhenPlannedThenKeepsOrDropsBars(t *testing.T) {
+	// Arrange
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		opts         Options
+		kept         int64
+		dropped      int64
+		materialized bool
+		deleted      bool
+	}{
+		"keep":         {opts: Options{KeepAggregates: true}, kept: 12, materialized: true},
+		"keep dry run": {opts: Options{KeepAggregates: true, DryRun: true}, kept: 10},
+		"drop":         {opts: Options{}, dropped: 10, deleted: true},
+		"drop dry run": {opts: Options{DryRun: true}, dropped: 10},
+	}
+
+	for name, tc := range cases {
+		bars := &mockDailyBarRepository{stored: 10}
+		s := &Service{bars: bars}
+		var report Report
+
+		// Act
+		err := s.planBars(context.Background(), nil, cutoff, tc.opts, &report)
+
+		// Assert
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.kept, report.BarsKept, name)
+		assert.Equal(t, tc.dropped, report.BarsDropped, name)
+		assert.Equal(t, tc.materialized, bars.materialized, name)
+		assert.Equal(t, tc.deleted, bars.deleted, name)
+	}
+}
+
+type mockBlockTradeRepository struct {
+	blocktrades.BlockTradeRepository
+	deleted bool
+}
+
+func (m *mockBlockTradeRepository) CountBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, int64, error) {
+	return 3, 40, nil
+}
+
+func (m *mockBlockTradeRepository) DeleteBefore(ctx context.Context, db *gorm.DB, cutoff time.Time) (int64, int64, error) {
+	m.deleted = true
+	return 3, 40, nil
+}
+
+func TestPlanBlockTradesGivenDryRunWhenPlannedThenCountsWithoutDeleting(t *testing.T) {
+	// Arrange
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, dryRun := range []bool{true, false} {
+		blocks := &mockBlockTradeRepository{}
+		s := &Service{blocks: blocks}
+		var report Report
+
+		// Act
+		err := s.planBlockTrades(context.Background(), nil, cutoff, Options{DryRun: dryRun}, &report)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), report.BlockTradesDeleted)
+		assert.Equal(t, int64(40), report.MediansDeleted)
+		assert.Equal(t, !dryRun, blocks.deleted)
+	}
+}
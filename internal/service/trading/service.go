@@ -1,15 +1,25 @@
 package trading
 
 import (
+	"b3-ingest/internal/domain/futures"
+	"b3-ingest/internal/domain/models"
 	"b3-ingest/internal/infra/repositories/trading"
 	"context"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type TradingService interface {
-	GetQuote(ctx context.Context, ticker string, startDate time.Time) (maxPrice float64, maxVol int64, err error)
+	GetQuote(ctx context.Context, ticker string, startDate, endDate time.Time) (models.Quote, error)
+	GetQuotes(ctx context.Context, tickers []string, startDate, endDate time.Time) (map[string]models.Quote, error)
+	GetCandles(ctx context.Context, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error)
+	GetDailySummary(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error)
+	GetTrades(ctx context.Context, query models.TradeQuery) (models.TradePage, error)
+	GetContinuous(ctx context.Context, root string, startDate, endDate time.Time, rule futures.RollRule) ([]futures.ContinuousBar, error)
+	GetVolumeProfile(ctx context.Context, ticker string, startDate, endDate time.Time, tickSize float64) (models.VolumeProfile, error)
+	GetRankings(ctx context.Context, query models.RankingQuery) (models.Rankings, error)
 }
 
 type tradingService struct {
@@ -21,10 +31,113 @@ func NewTradingService(repo trading.TradingRepository, db *gorm.DB) TradingServi
 	return &tradingService{repo: repo, db: db}
 }
 
-func (s *tradingService) GetQuote(ctx context.Context, ticker string, startDate time.Time) (float64, int64, error) {
-	stats, err := s.repo.GetQuoteStats(ctx, s.db, ticker, startDate)
+func (s *tradingService) GetQuote(ctx context.Context, ticker string, startDate, endDate time.Time) (models.Quote, error) {
+	stats, err := s.repo.GetQuoteStats(ctx, s.db, ticker, startDate, endDate)
 	if err != nil {
-		return 0, 0, err
+		return models.Quote{}, err
 	}
-	return stats.MaxPrice, stats.MaxDailyVolume, nil
+	return newQuote(ticker, stats), nil
+}
+
+// GetQuotes returns the quotes of several tickers at once. Known tickers without sessions in the
+// range have zero Sessions; tickers that were never traded are left out of the map.
+func (s *tradingService) GetQuotes(ctx context.Context, tickers []string, startDate, endDate time.Time) (map[string]models.Quote, error) {
+	stats, err := s.repo.GetQuoteStatsBatch(ctx, s.db, tickers, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	quotes := make(map[string]models.Quote, len(stats))
+	for ticker, st := range stats {
+		quotes[ticker] = newQuote(ticker, st)
+	}
+	return quotes, nil
+}
+
+func newQuote(ticker string, stats trading.QuoteStats) models.Quote {
+	return models.Quote{
+		Ticker:         ticker,
+		Sessions:       stats.Sessions,
+		MaxPrice:       stats.MaxPrice,
+		MinPrice:       stats.MinPrice,
+		OpenPrice:      stats.OpenPrice,
+		ClosePrice:     stats.ClosePrice,
+		VWAP:           stats.VWAP,
+		MaxDailyVolume: stats.MaxDailyVolume,
+		TotalVolume:    stats.TotalVolume,
+		TradeCount:     stats.TradeCount,
+		MaxPriceDate:   stats.MaxPriceDate,
+		MinPriceDate:   stats.MinPriceDate,
+	}
+}
+
+func (s *tradingService) GetCandles(ctx context.Context, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error) {
+	return s.repo.GetCandles(ctx, s.db, ticker, date, interval)
+}
+
+// GetDailySummary returns the daily bars of ticker between startDate and endDate. Unknown tickers
+// return models.ErrTickerNotFound; known tickers without sessions in the range return no days.
+func (s *tradingService) GetDailySummary(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error) {
+	days, err := s.repo.GetDailySummaries(ctx, s.db, ticker, startDate, endDate)
+	if err != nil || len(days) > 0 {
+		return days, err
+	}
+	exists, err := s.repo.TickerExists(ctx, s.db, ticker)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrTickerNotFound
+	}
+	return days, nil
+}
+
+// GetTrades returns a page of query.Limit trades. One extra trade is read to tell whether another
+// page follows, in which case Next points at the last trade of this page.
+func (s *tradingService) GetTrades(ctx context.Context, query models.TradeQuery) (models.TradePage, error) {
+	limit := query.Limit
+	if limit < 1 {
+		return models.TradePage{}, fmt.Errorf("trade page limit must be at least 1, got %d", limit)
+	}
+	query.Limit++
+	trades, err := s.repo.GetTrades(ctx, s.db, query)
+	if err != nil {
+		return models.TradePage{}, err
+	}
+	page := models.TradePage{Trades: trades}
+	if len(trades) > limit {
+		page.Trades = trades[:limit]
+		next := page.Trades[limit-1].Cursor()
+		page.Next = &next
+	}
+	return page, nil
+}
+
+// GetContinuous builds the continuous front-month series of a futures root. Roots without a known
+// expiry rule return futures.ErrUnknownRoot.
+func (s *tradingService) GetContinuous(ctx context.Context, root string, startDate, endDate time.Time, rule futures.RollRule) ([]futures.ContinuousBar, error) {
+	if !futures.KnownRoot(root) {
+		return nil, fmt.Errorf("%w %q", futures.ErrUnknownRoot, root)
+	}
+	bars, err := s.repo.GetContractBars(ctx, s.db, root, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return futures.Continuous(bars, rule)
+}
+
+// GetVolumeProfile reads one level more than models.MaxPriceLevels, so a tick size that splits the
+// range into too many levels returns models.ErrTooManyPriceLevels without reading them all.
+func (s *tradingService) GetVolumeProfile(ctx context.Context, ticker string, startDate, endDate time.Time, tickSize float64) (models.VolumeProfile, error) {
+	levels, err := s.repo.GetPriceLevels(ctx, s.db, ticker, startDate, endDate, tickSize, models.MaxPriceLevels+1)
+	if err != nil {
+		return models.VolumeProfile{}, err
+	}
+	if len(levels) > models.MaxPriceLevels {
+		return models.VolumeProfile{}, fmt.Errorf("%w: more than %d, use a larger tick_size", models.ErrTooManyPriceLevels, models.MaxPriceLevels)
+	}
+	return models.NewVolumeProfile(levels), nil
+}
+
+func (s *tradingService) GetRankings(ctx context.Context, query models.RankingQuery) (models.Rankings, error) {
+	return s.repo.GetRankings(ctx, s.db, query)
 }
@@ -0,0 +1,311 @@
+package trading
+
+import (
+	"b3-ingest/internal/domain/futures"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/infra/repositories/trading"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockTradingRepository struct {
+	trading.TradingRepository
+	summaries []models.DailySummary
+	err       error
+	gotStart  time.Time
+	gotEnd    time.Time
+	trades    []models.Trade
+	gotLimit  int
+
+	contractBars []futures.Bar
+	levels       []models.PriceLevel
+	gotTickSize  float64
+	gotRanking   models.RankingQuery
+	known        map[string]bool
+}
+
+func (m *mockTradingRepository) GetQuoteStats(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) (trading.QuoteStats, error) {
+	m.gotStart, m.gotEnd = startDate, endDate
+	maxDate := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	return trading.QuoteStats{MaxPrice: 10, MaxDailyVolume: 20, MinPrice: 8, VWAP: 9.5, TotalVolume: 35, TradeCount: 4, MaxPriceDate: &maxDate}, m.err
+}
+
+func (m *mockTradingRepository) GetQuoteStatsBatch(ctx context.Context, db *gorm.DB, tickers []string, startDate, endDate time.Time) (map[string]trading.QuoteStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return map[string]trading.QuoteStats{tickers[0]: {MaxPrice: 10, TradeCount: 4}}, nil
+}
+
+func (m *mockTradingRepository) TickerExists(ctx context.Context, db *gorm.DB, ticker string) (bool, error) {
+	return m.known[ticker], nil
+}
+
+func (m *mockTradingRepository) GetDailySummaries(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error) {
+	m.gotStart, m.gotEnd = startDate, endDate
+	return m.summaries, m.err
+}
+
+func (m *mockTradingRepository) GetTrades(ctx context.Context, db *gorm.DB, query models.TradeQuery) ([]models.Trade, error) {
+	m.gotLimit = query.Limit
+	if len(m.trades) > query.Limit {
+		return m.trades[:query.Limit], m.err
+	}
+	return m.trades, m.err
+}
+
+func (m *mockTradingRepository) GetContractBars(ctx context.Context, db *gorm.DB, root string, startDate, endDate time.Time) ([]futures.Bar, error) {
+	return m.contractBars, m.err
+}
+
+func (m *mockTradingRepository) GetPriceLevels(ctx context.Context, db *gorm.DB, ticker string, startDate, endDate time.Time, tickSize float64, limit int) ([]models.PriceLevel, error) {
+	m.gotTickSize = tickSize
+	if len(m.levels) > limit {
+		return m.levels[:limit], m.err
+	}
+	return m.levels, m.err
+}
+
+func (m *mockTradingRepository) GetRankings(ctx context.Context, db *gorm.DB, query models.RankingQuery) (models.Rankings, error) {
+	m.gotRanking = query
+	return models.Rankings{Top: []models.RankingEntry{{Ticker: "PETR4"}}}, m.err
+}
+
+func TestGetQuoteGivenRepositoryStatsWhenCalledThenReturnsMaxValues(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{}, nil)
+
+	// Act
+	quote, err := svc.GetQuote(context.Background(), "PETR4", time.Now(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "PETR4", quote.Ticker)
+	assert.Equal(t, 10.0, quote.MaxPrice)
+	assert.Equal(t, int64(20), quote.MaxDailyVolume)
+	assert.Equal(t, 8.0, quote.MinPrice)
+	assert.Equal(t, 9.5, quote.VWAP)
+	assert.Equal(t, int64(35), quote.TotalVolume)
+	assert.Equal(t, int64(4), quote.TradeCount)
+	assert.Equal(t, time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC), *quote.MaxPriceDate)
+}
+
+func TestGetQuoteGivenUnknownTickerWhenCalledThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{err: models.ErrTickerNotFound}, nil)
+
+	// Act
+	_, err := svc.GetQuote(context.Background(), "FOO", time.Now(), time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+}
+
+func TestGetQuotesGivenRepositoryStatsWhenCalledThenReturnsOnlyKnownTickers(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{}, nil)
+
+	// Act
+	quotes, err := svc.GetQuotes(context.Background(), []string{"PETR4", "FOO"}, time.Now(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, quotes, 1)
+	assert.Equal(t, "PETR4", quotes["PETR4"].Ticker)
+	assert.Equal(t, 10.0, quotes["PETR4"].MaxPrice)
+}
+
+func TestGetDailySummaryGivenRangeWhenCalledThenDelegatesToRepository(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{summaries: []models.DailySummary{{Close: 10}}}
+	svc := NewTradingService(repo, nil)
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	days, err := svc.GetDailySummary(context.Background(), "PETR4", start, end)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, days, 1)
+	assert.Equal(t, start, repo.gotStart)
+	assert.Equal(t, end, repo.gotEnd)
+}
+
+func TestGetDailySummaryGivenRepositoryErrorWhenCalledThenReturnsError(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{err: assert.AnError}, nil)
+
+	// Act
+	_, err := svc.GetDailySummary(context.Background(), "PETR4", time.Now(), time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGetDailySummaryGivenUnknownTickerWhenCalledThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{}, nil)
+
+	// Act
+	_, err := svc.GetDailySummary(context.Background(), "FOO", time.Now(), time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTickerNotFound)
+}
+
+func TestGetDailySummaryGivenKnownTickerWithoutSessionsWhenCalledThenReturnsNoDays(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{known: map[string]bool{"PETR4": true}}, nil)
+
+	// Act
+	days, err := svc.GetDailySummary(context.Background(), "PETR4", time.Now(), time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, days)
+}
+
+func TestGetTradesGivenMoreTradesThanLimitWhenCalledThenReturnsNextCursor(t *testing.T) {
+	// Arrange
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	repo := &mockTradingRepository{trades: []models.Trade{
+		{Date: day, ClosingTime: 90000000, TradeID: 1},
+		{Date: day, ClosingTime: 90000000, TradeID: 2},
+		{Date: day, ClosingTime: 90000001, TradeID: 3},
+	}}
+	svc := NewTradingService(repo, nil)
+
+	// Act
+	page, err := svc.GetTrades(context.Background(), models.TradeQuery{Ticker: "PETR4", Limit: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, repo.gotLimit)
+	assert.Len(t, page.Trades, 2)
+	assert.Equal(t, &models.TradeCursor{Date: day, ClosingTime: 90000000, TradeID: 2}, page.Next)
+}
+
+func TestGetTradesGivenLastPageWhenCalledThenHasNoNextCursor(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{trades: []models.Trade{{TradeID: 1}}}
+	svc := NewTradingService(repo, nil)
+
+	// Act
+	page, err := svc.GetTrades(context.Background(), models.TradeQuery{Ticker: "PETR4", Limit: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, page.Trades, 1)
+	assert.Nil(t, page.Next)
+}
+
+func TestGetTradesGivenZeroLimitWhenCalledThenReturnsError(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{trades: []models.Trade{{TradeID: 1}}}
+	svc := NewTradingService(repo, nil)
+
+	// Act
+	_, err := svc.GetTrades(context.Background(), models.TradeQuery{Ticker: "PETR4", Limit: 0})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestGetContinuousGivenContractBarsWhenCalledThenStitchesSeries(t *testing.T) {
+	// Arrange
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	q25, _ := futures.ParseContract("WDOQ25")
+	u25, _ := futures.ParseContract("WDOU25")
+	repo := &mockTradingRepository{contractBars: []futures.Bar{
+		{Contract: q25, Date: day, Volume: 100},
+		{Contract: u25, Date: day, Volume: 10},
+	}}
+	svc := NewTradingService(repo, nil)
+	rule, _ := futures.ParseRollRule("volume", 0)
+
+	// Act
+	series, err := svc.GetContinuous(context.Background(), "WDO", day, day, rule)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, series, 1)
+	assert.Equal(t, "WDOQ25", series[0].Contract.Code)
+}
+
+func TestGetContinuousGivenUnknownRootWhenCalledThenReturnsUnknownRoot(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{}, nil)
+	rule, _ := futures.ParseRollRule("volume", 0)
+
+	// Act
+	_, err := svc.GetContinuous(context.Background(), "XYZ", time.Now(), time.Now(), rule)
+
+	// Assert
+	assert.ErrorIs(t, err, futures.ErrUnknownRoot)
+}
+
+func TestGetVolumeProfileGivenPriceLevelsWhenCalledThenReturnsPOC(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{levels: []models.PriceLevel{
+		{Price: 32.0, Volume: 100},
+		{Price: 32.1, Volume: 250},
+	}}
+	svc := NewTradingService(repo, nil)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	profile, err := svc.GetVolumeProfile(context.Background(), "PETR4", day, day, 0.1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, repo.gotTickSize)
+	assert.Equal(t, 32.1, profile.POC)
+	assert.Equal(t, int64(350), profile.TotalVolume)
+}
+
+func TestGetVolumeProfileGivenMoreLevelsThanMaximumWhenCalledThenReturnsTooManyLevels(t *testing.T) {
+	// Arrange
+	levels := make([]models.PriceLevel, models.MaxPriceLevels+10)
+	for i := range levels {
+		levels[i] = models.PriceLevel{Price: float64(i), Volume: 1}
+	}
+	svc := NewTradingService(&mockTradingRepository{levels: levels}, nil)
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := svc.GetVolumeProfile(context.Background(), "PETR4", day, day, 0.000001)
+
+	// Assert
+	assert.ErrorIs(t, err, models.ErrTooManyPriceLevels)
+}
+
+func TestGetVolumeProfileGivenRepositoryErrorWhenCalledThenReturnsError(t *testing.T) {
+	// Arrange
+	svc := NewTradingService(&mockTradingRepository{err: assert.AnError}, nil)
+
+	// Act
+	_, err := svc.GetVolumeProfile(context.Background(), "PETR4", time.Now(), time.Now(), 0.01)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestGetRankingsGivenQueryWhenCalledThenDelegatesToRepository(t *testing.T) {
+	// Arrange
+	repo := &mockTradingRepository{}
+	svc := NewTradingService(repo, nil)
+	query := models.RankingQuery{Metric: models.RankByTrades, Prefix: "PETR", Limit: 5}
+
+	// Act
+	rankings, err := svc.GetRankings(context.Background(), query)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, query, repo.gotRanking)
+	assert.Equal(t, "PETR4", rankings.Top[0].Ticker)
+}
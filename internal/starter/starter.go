@@ -1,30 +1,107 @@
 package starter
 
 import (
+	"b3-ingest/internal/domain/calendar"
+	"b3-ingest/internal/domain/futures"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/domain/quality"
 	"b3-ingest/internal/infra/adapter/database"
+	"b3-ingest/internal/infra/adapter/database/migrations"
 	"b3-ingest/internal/infra/adapter/database/provider/postgres"
+	"b3-ingest/internal/infra/repositories/blocktrades"
+	"b3-ingest/internal/infra/repositories/brokers"
+	"b3-ingest/internal/infra/repositories/instruments"
+	qualityRepo "b3-ingest/internal/infra/repositories/quality"
 	"b3-ingest/internal/infra/repositories/trading"
 	"b3-ingest/internal/logger"
+	blockTradeServicePkg "b3-ingest/internal/service/blocktrades"
+	brokerServicePkg "b3-ingest/internal/service/brokers"
+	comparisonServicePkg "b3-ingest/internal/service/comparison"
+	exportServicePkg "b3-ingest/internal/service/export"
+	indicatorServicePkg "b3-ingest/internal/service/indicators"
 	"b3-ingest/internal/service/ingestion"
+	instrumentServicePkg "b3-ingest/internal/service/instruments"
+	qualityServicePkg "b3-ingest/internal/service/quality"
+	"b3-ingest/internal/service/retention"
 	tradingServicePkg "b3-ingest/internal/service/trading"
+	blockTradeRoute "b3-ingest/pkg/routes/v1/blocktrades"
+	brokerRoute "b3-ingest/pkg/routes/v1/brokers"
+	compareRoute "b3-ingest/pkg/routes/v1/compare"
+	continuousRoute "b3-ingest/pkg/routes/v1/continuous"
+	exportRoute "b3-ingest/pkg/routes/v1/export"
+	indicatorRoute "b3-ingest/pkg/routes/v1/indicators"
+	instrumentRoute "b3-ingest/pkg/routes/v1/instruments"
+	qualityRoute "b3-ingest/pkg/routes/v1/quality"
+	rankingRoute "b3-ingest/pkg/routes/v1/rankings"
 	tradingRoute "b3-ingest/pkg/routes/v1/trading"
+	volumeProfileRoute "b3-ingest/pkg/routes/v1/volumeprofile"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type StarterConfig struct {
-	Mode     string
-	CSVPath  string
-	AppPort  string
-	DSN      string
-	DBConfig database.Config
-	Logger   *logger.Logger
+	Mode    string
+	CSVPath string
+	AppPort string
+	DSN     string
+	Watch   ingestion.WatchOptions
+	Filter  ingestion.FilterConfig
+	// PartitionGranularity is the raw PARTITION_GRANULARITY value, validated when an ingestion mode starts.
+	PartitionGranularity string
+	// MigrateCommand is the -migrate argument: up, down or status.
+	MigrateCommand string
+	// QuoteMaxRangeDays caps the date range accepted by the quote endpoints, zero means no limit.
+	QuoteMaxRangeDays int
+	// TradesMaxPageSize is the largest page /v1/trades returns.
+	TradesMaxPageSize int
+	// ContinuousRollMethod and ContinuousRollDays are the default roll rule of /v1/continuous.
+	ContinuousRollMethod string
+	ContinuousRollDays   int
+	// BrokersFile is the -brokers argument: the participant code to broker name mapping to load.
+	BrokersFile string
+	// BlockTrades is the rule ingestion uses to flag large trades.
+	BlockTrades models.BlockTradeRule
+	// Quality holds the thresholds of -check and /v1/quality.
+	Quality   quality.Options
+	Check     CheckConfig
+	Retention retention.Options
+	Export    ExportConfig
+	DBConfig  database.Config
+	Logger    *logger.Logger
+}
+
+// CheckConfig holds the raw -check flags, validated when the mode starts.
+type CheckConfig struct {
+	From string
+	To   string
+	Out  string
+}
+
+// checkIssuesExitCode is the exit status of -check when the report lists any issue, so scripts
+// can tell findings apart from failures.
+const checkIssuesExitCode = 2
+
+// defaultCheckDays is the range -check covers when -from is not given, in calendar days.
+const defaultCheckDays = 30
+
+// ExportConfig holds the raw -export flags, validated when the mode starts.
+type ExportConfig struct {
+	Ticker  string
+	From    string
+	To      string
+	Dataset string
+	Format  string
+	Out     string
 }
 
 func Start(cfg StarterConfig) {
@@ -33,14 +110,253 @@ func Start(cfg StarterConfig) {
 		startDownload(cfg)
 	case "load":
 		startIngestion(cfg)
+	case "watch":
+		startWatch(cfg)
 	case "serve":
 		startServer(cfg)
+	case "migrate":
+		startMigrate(cfg)
+	case "purge":
+		startPurge(cfg)
+	case "export":
+		startExport(cfg)
+	case "brokers":
+		startBrokers(cfg)
+	case "check":
+		startCheck(cfg)
 	default:
 		fmt.Println("Usage:")
 		fmt.Println("  b3-ingest -load   # Load CSV files into the database")
+		fmt.Println("  b3-ingest -watch  # Watch CSV_PATH and ingest new files as they arrive")
 		fmt.Println("  b3-ingest -serve  # Run HTTP server with trading routes")
+		fmt.Println("  b3-ingest -migrate up|down|status  # Manage the database schema")
+		fmt.Println("  b3-ingest -purge [-dry-run]        # Delete trades older than RETENTION_MONTHS")
+		fmt.Println("  b3-ingest -export -ticker T -from D -to D -out FILE [-dataset trades|daily] [-format csv|ndjson|parquet]")
+		fmt.Println("  b3-ingest -brokers FILE  # Load the participant code to broker name mapping")
+		fmt.Println("  b3-ingest -check [-from D] [-to D] [-out FILE]  # Report missing days and data anomalies as JSON")
+		os.Exit(1)
+	}
+}
+
+// openDatabase connects to the database and checks that the schema is up to date, exiting when
+// either step fails. Migrations are only applied by -migrate up.
+func openDatabase(cfg StarterConfig) *gorm.DB {
+	db, err := postgres.NewPostgres(cfg.DBConfig)
+	if err != nil {
+		cfg.Logger.Error("Error connecting to database: %v", err)
+		os.Exit(1)
+	}
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		cfg.Logger.Error("Error loading schema migrations: %v", err)
+		os.Exit(1)
+	}
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		cfg.Logger.Error("Error reading schema migrations: %v", err)
 		os.Exit(1)
 	}
+	if len(pending) > 0 {
+		cfg.Logger.Error("The database schema has %d pending migrations, starting with %04d_%s. Run -migrate up first.",
+			len(pending), pending[0].Version, pending[0].Name)
+		os.Exit(1)
+	}
+	return db
+}
+
+func startMigrate(cfg StarterConfig) {
+	db, err := postgres.NewPostgres(cfg.DBConfig)
+	if err != nil {
+		cfg.Logger.Error("Error connecting to database: %v", err)
+		os.Exit(1)
+	}
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		cfg.Logger.Error("Error loading schema migrations: %v", err)
+		os.Exit(1)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	switch cfg.MigrateCommand {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			cfg.Logger.Info("Applied migration %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			cfg.Logger.Error("Error applying schema migrations: %v", err)
+			os.Exit(1)
+		}
+		cfg.Logger.Info("Schema is up to date.")
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			cfg.Logger.Error("Error reverting schema migration: %v", err)
+			os.Exit(1)
+		}
+		if reverted == nil {
+			cfg.Logger.Info("No migration to revert.")
+			return
+		}
+		cfg.Logger.Info("Reverted migration %04d_%s", reverted.Version, reverted.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			cfg.Logger.Error("Error reading schema migrations: %v", err)
+			os.Exit(1)
+		}
+		for _, st := range statuses {
+			if st.Applied {
+				cfg.Logger.Info("%04d_%s applied at %s", st.Version, st.Name, st.AppliedAt.Format(time.RFC3339))
+			} else {
+				cfg.Logger.Info("%04d_%s pending", st.Version, st.Name)
+			}
+		}
+	default:
+		cfg.Logger.Error("Unknown migrate command %q, use up, down or status", cfg.MigrateCommand)
+		os.Exit(1)
+	}
+}
+
+func startPurge(cfg StarterConfig) {
+	db := openDatabase(cfg)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	report, err := retention.NewService(db, cfg.Logger).Purge(ctx, cfg.Retention)
+	if err != nil {
+		cfg.Logger.Error("Purge failed, nothing was deleted: %v", err)
+		os.Exit(1)
+	}
+	verb := "Purged"
+	if report.DryRun {
+		verb = "Would purge"
+	}
+	cfg.Logger.Info("%s trades before %s: partitions dropped=%v rows=%d reclaimed=%.2f MB daily bars kept=%d dropped=%d block trades=%d medians=%d",
+		verb, report.Cutoff.Format("2006-01-02"), report.PartitionsDropped, report.RowsDeleted,
+		float64(report.BytesReclaimed)/1024/1024, report.BarsKept, report.BarsDropped, report.BlockTradesDeleted, report.MediansDeleted)
+}
+
+func startExport(cfg StarterConfig) {
+	e := cfg.Export
+	req, err := models.NewExportRequest(e.Ticker, e.From, e.To, e.Dataset, e.Format)
+	if err != nil {
+		cfg.Logger.Error("Invalid export: %v", err)
+		os.Exit(1)
+	}
+	if e.Out == "" {
+		cfg.Logger.Error("Invalid export: -out is required")
+		os.Exit(1)
+	}
+	db := openDatabase(cfg)
+	f, err := os.Create(e.Out)
+	if err != nil {
+		cfg.Logger.Error("Error creating %s: %v", e.Out, err)
+		os.Exit(1)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	cfg.Logger.Info("Exporting %s %s from %s to %s as %s...", req.Ticker, req.Dataset, e.From, e.To, req.Format)
+	start := time.Now()
+	rows, err := exportServicePkg.NewExportService(trading.NewTradingRepository(), db).Export(ctx, f, req)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(e.Out)
+		cfg.Logger.Error("Export failed after %d rows, removed %s: %v", rows, e.Out, err)
+		os.Exit(1)
+	}
+	cfg.Logger.Info("Exported %d rows to %s in %.2fs", rows, e.Out, time.Since(start).Seconds())
+}
+
+func startCheck(cfg StarterConfig) {
+	if err := cfg.Quality.Validate(); err != nil {
+		cfg.Logger.Error("Invalid quality thresholds: %v", err)
+		os.Exit(1)
+	}
+	if cfg.TradesMaxPageSize < 1 {
+		cfg.Logger.Error("TRADES_MAX_PAGE_SIZE must be at least 1, got %d", cfg.TradesMaxPageSize)
+		os.Exit(1)
+	}
+	// Today's session is only loaded after the close, so the default ends at the session before it.
+	to := calendar.AddTradingDays(time.Now(), -1)
+	var err error
+	if cfg.Check.To != "" {
+		if to, err = time.Parse("2006-01-02", cfg.Check.To); err != nil {
+			cfg.Logger.Error("Invalid check: -to must use format YYYY-MM-DD")
+			os.Exit(1)
+		}
+	}
+	from := to.AddDate(0, 0, -defaultCheckDays)
+	if cfg.Check.From != "" {
+		if from, err = time.Parse("2006-01-02", cfg.Check.From); err != nil {
+			cfg.Logger.Error("Invalid check: -from must use format YYYY-MM-DD")
+			os.Exit(1)
+		}
+	}
+	if from.After(to) {
+		cfg.Logger.Error("Invalid check: -from must not be after -to")
+		os.Exit(1)
+	}
+	if cfg.Check.Out == "" {
+		// The report goes to stdout, which the logger shares, so only errors are logged.
+		cfg.Logger.SetMinLevel(logger.ERROR)
+	}
+	db := openDatabase(cfg)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	cfg.Logger.Info("Checking data from %s to %s...", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	report, err := qualityServicePkg.NewQualityService(qualityRepo.NewQualityRepository(), db, cfg.Quality).Check(ctx, from, to)
+	if err != nil {
+		cfg.Logger.Error("Check failed: %v", err)
+		os.Exit(1)
+	}
+
+	if err := writeCheckReport(cfg.Check.Out, report); err != nil {
+		cfg.Logger.Error("Error writing the report: %v", err)
+		os.Exit(1)
+	}
+	if !report.Clean() {
+		cfg.Logger.Warning("Check found issues: missing=%d unexpected=%d row anomalies=%d price jumps=%d duplicate trades=%d",
+			len(report.MissingDates), len(report.UnexpectedDates), len(report.RowAnomalies),
+			len(report.PriceJumps), len(report.DuplicateTrades))
+		os.Exit(checkIssuesExitCode)
+	}
+	cfg.Logger.Info("Check found no issues in %d loaded days", report.LoadedDays)
+}
+
+// writeCheckReport writes report as indented JSON to the file out, or to stdout when out is empty.
+func writeCheckReport(out string, report quality.Report) error {
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func startBrokers(cfg StarterConfig) {
+	f, err := os.Open(cfg.BrokersFile)
+	if err != nil {
+		cfg.Logger.Error("Error opening %s: %v", cfg.BrokersFile, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	db := openDatabase(cfg)
+	service := brokerServicePkg.NewBrokerService(brokers.NewBrokerRepository(), trading.NewTradingRepository(), db)
+	loaded, err := service.LoadBrokers(context.Background(), f)
+	if err != nil {
+		cfg.Logger.Error("Error loading brokers from %s: %v", cfg.BrokersFile, err)
+		os.Exit(1)
+	}
+	cfg.Logger.Info("Loaded %d brokers from %s", loaded, cfg.BrokersFile)
 }
 
 func startDownload(cfg StarterConfig) {
@@ -56,34 +372,108 @@ func startDownload(cfg StarterConfig) {
 }
 
 func startIngestion(cfg StarterConfig) {
-	db, err := postgres.NewPostgres(cfg.DBConfig)
+	db := openDatabase(cfg)
+	ingestionService := newIngestionService(cfg, db)
+	cfg.Logger.Info("Starting CSV ingestion mode...")
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	start := time.Now()
+	report, err := ingestionService.IngestFromCSV(ctx, cfg.CSVPath)
+	logIngestionReport(cfg.Logger, report, time.Since(start))
 	if err != nil {
-		cfg.Logger.Error("Error connecting to database: %v", err)
+		if report.Aborted {
+			cfg.Logger.Error("Ingestion aborted by signal, staged rows were discarded: %v", err)
+		} else {
+			cfg.Logger.Error("Error loading CSV data: %v", err)
+		}
 		os.Exit(1)
 	}
-	cfg.Logger.Info("Starting CSV ingestion mode...")
-	start := time.Now()
-	ingestionService := ingestion.NewService(db, cfg.DSN, cfg.Logger)
-	err = ingestionService.IngestFromCSV(cfg.CSVPath)
+}
+
+// newIngestionService builds the ingestion service with the configured filter, partitioning and
+// block trade rule, exiting when any of them is invalid.
+func newIngestionService(cfg StarterConfig, db *gorm.DB) *ingestion.Service {
+	filter, err := ingestion.NewFilter(cfg.Filter)
+	if err != nil {
+		cfg.Logger.Error("Invalid ingestion filter: %v", err)
+		os.Exit(1)
+	}
+	granularity, err := ingestion.ParseGranularity(cfg.PartitionGranularity)
 	if err != nil {
-		cfg.Logger.Error("Error loading CSV data: %v", err)
+		cfg.Logger.Error("Invalid partitioning: %v", err)
+		os.Exit(1)
+	}
+	if err := cfg.BlockTrades.Validate(); err != nil {
+		cfg.Logger.Error("Invalid block trade rule: %v", err)
+		os.Exit(1)
+	}
+	ingestionService := ingestion.NewService(db, cfg.DSN, cfg.Logger)
+	ingestionService.Filter = filter
+	ingestionService.Granularity = granularity
+	ingestionService.BlockTrades = cfg.BlockTrades
+	return ingestionService
+}
+
+func logIngestionReport(log *logger.Logger, report ingestion.Report, elapsed time.Duration) {
+	log.Info("Ingestion report: files=%d processed=%d failed=%d skipped=%d rows=%d filtered=%d aborted=%t elapsed=%.2fs",
+		report.FilesTotal, report.FilesProcessed, report.FilesFailed, report.FilesSkipped,
+		report.RowsCopied, report.RowsFiltered, report.Aborted, elapsed.Seconds())
+}
+
+func startWatch(cfg StarterConfig) {
+	if err := cfg.Watch.Validate(); err != nil {
+		cfg.Logger.Error("Invalid watch options: %v", err)
+		os.Exit(1)
+	}
+	db := openDatabase(cfg)
+	ingestionService := newIngestionService(cfg, db)
+	cfg.Logger.Info("Starting watch-folder ingestion mode...")
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := ingestionService.Watch(ctx, cfg.CSVPath, cfg.Watch); err != nil {
+		cfg.Logger.Error("Watch mode stopped: %v", err)
 		os.Exit(1)
 	}
-	elapsed := time.Since(start)
-	cfg.Logger.Info("LoadFromCSV completed: %f", elapsed.Seconds())
 }
 
 func startServer(cfg StarterConfig) {
-	db, err := postgres.NewPostgres(cfg.DBConfig)
+	rollRule, err := futures.ParseRollRule(cfg.ContinuousRollMethod, cfg.ContinuousRollDays)
 	if err != nil {
-		cfg.Logger.Error("Error connecting to database: %v", err)
+		cfg.Logger.Error("Invalid continuous roll rule: %v", err)
+		os.Exit(1)
+	}
+	if err := cfg.Quality.Validate(); err != nil {
+		cfg.Logger.Error("Invalid quality thresholds: %v", err)
 		os.Exit(1)
 	}
+	db := openDatabase(cfg)
 	cfg.Logger.Info("Starting HTTP server mode...")
 	repo := trading.NewTradingRepository()
 	service := tradingServicePkg.NewTradingService(repo, db)
+	exportService := exportServicePkg.NewExportService(repo, db)
+	comparisonService := comparisonServicePkg.NewComparisonService(repo, db)
+	indicatorService := indicatorServicePkg.NewIndicatorService(repo, db)
+	instrumentService := instrumentServicePkg.NewInstrumentService(instruments.NewInstrumentRepository(), db)
+	qualityService := qualityServicePkg.NewQualityService(qualityRepo.NewQualityRepository(), db, cfg.Quality)
+	brokerService := brokerServicePkg.NewBrokerService(brokers.NewBrokerRepository(), repo, db)
+	blockTradeService := blockTradeServicePkg.NewBlockTradeService(blocktrades.NewBlockTradeRepository(), db)
 	r := gin.Default()
-	r.GET("/quote", tradingRoute.GetQuoteHandler(service))
+	r.GET("/quote", tradingRoute.GetQuoteHandler(service, cfg.QuoteMaxRangeDays))
+	v1 := r.Group("/v1")
+	v1.POST("/quotes", tradingRoute.GetQuotesHandler(service, cfg.QuoteMaxRangeDays))
+	v1.GET("/candles", tradingRoute.GetCandlesHandler(service))
+	v1.GET("/daily", tradingRoute.GetDailySummaryHandler(service))
+	v1.GET("/trades", tradingRoute.GetTradesHandler(service, cfg.TradesMaxPageSize))
+	v1.GET("/rankings", rankingRoute.GetRankingsHandler(service, cfg.QuoteMaxRangeDays))
+	v1.GET("/compare", compareRoute.GetCompareHandler(comparisonService))
+	v1.GET("/indicators", indicatorRoute.GetIndicatorsHandler(indicatorService))
+	v1.GET("/broker-flow", brokerRoute.GetBrokerFlowHandler(brokerService, cfg.QuoteMaxRangeDays))
+	v1.GET("/volume-profile", volumeProfileRoute.GetVolumeProfileHandler(service, cfg.QuoteMaxRangeDays))
+	v1.GET("/continuous", continuousRoute.GetContinuousHandler(service, rollRule))
+	v1.GET("/quality", qualityRoute.GetQualityHandler(qualityService, cfg.QuoteMaxRangeDays))
+	v1.GET("/export", exportRoute.GetExportHandler(exportService))
+	v1.GET("/tickers", instrumentRoute.GetTickersHandler(instrumentService))
+	v1.GET("/block-trades", blockTradeRoute.GetBlockTradesHandler(blockTradeService))
 	port := cfg.AppPort
 	if port == "" {
 		port = "8000"
@@ -1,10 +1,14 @@
 package starter
 
 import (
+	"b3-ingest/internal/domain/quality"
 	"b3-ingest/internal/logger"
+	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -34,3 +38,22 @@ func TestStartGivenUnknownModeWhenCalledThenExitsWithUsage(t *testing.T) {
 	assert.NotEqual(t, 0, state.ExitCode())
 	os.Unsetenv("BE_CRASHER")
 }
+
+func TestWriteCheckReportGivenOutFileWhenCalledThenWritesJSONReport(t *testing.T) {
+	// Arrange
+	out := filepath.Join(t.TempDir(), "report.json")
+	day := time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC)
+	report := quality.Report{From: day, To: day, ExpectedDays: 1, MissingDates: []time.Time{day}}
+
+	// Act
+	err := writeCheckReport(out, report)
+
+	// Assert
+	assert.NoError(t, err)
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, false, decoded["ok"])
+	assert.Equal(t, []interface{}{"2025-07-29"}, decoded["missing_dates"])
+}
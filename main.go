@@ -1,9 +1,13 @@
 package main
 
 import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/internal/domain/quality"
 	"b3-ingest/internal/infra/adapter/database"
 	"b3-ingest/internal/infra/settings"
 	"b3-ingest/internal/logger"
+	"b3-ingest/internal/service/ingestion"
+	"b3-ingest/internal/service/retention"
 	"b3-ingest/internal/starter"
 	"flag"
 	"fmt"
@@ -15,6 +19,19 @@ func main() {
 		loadFlag     = flag.Bool("load", false, "Load CSV files into the database")
 		serveFlag    = flag.Bool("serve", false, "Run HTTP server with trading routes")
 		downloadFlag = flag.Bool("download", false, "Download and unzip last 7 workdays' files to bundle/b3files")
+		watchFlag    = flag.Bool("watch", false, "Watch CSV_PATH and ingest new CSV/zip files as they arrive")
+		migrateFlag  = flag.String("migrate", "", "Manage the database schema: up, down or status")
+		purgeFlag    = flag.Bool("purge", false, "Delete trades older than the RETENTION_MONTHS window")
+		dryRunFlag   = flag.Bool("dry-run", false, "With -purge, only report what would be deleted")
+		exportFlag   = flag.Bool("export", false, "Export the trades or daily bars of a ticker to a file")
+		checkFlag    = flag.Bool("check", false, "Check the loaded data for missing days, row count anomalies, price jumps and duplicate trades")
+		tickerFlag   = flag.String("ticker", "", "With -export, the instrument code")
+		fromFlag     = flag.String("from", "", "With -export or -check, first date (YYYY-MM-DD)")
+		toFlag       = flag.String("to", "", "With -export or -check, last date (YYYY-MM-DD)")
+		datasetFlag  = flag.String("dataset", "daily", "With -export, trades or daily")
+		formatFlag   = flag.String("format", "csv", "With -export, csv, ndjson or parquet")
+		outFlag      = flag.String("out", "", "With -export or -check, the output file")
+		brokersFlag  = flag.String("brokers", "", "Load the participant code to broker name mapping from a file")
 	)
 	flag.Parse()
 
@@ -27,19 +44,74 @@ func main() {
 	log := logger.GetDefaultLogger()
 
 	mode := ""
-	if *downloadFlag {
+	if *migrateFlag != "" {
+		mode = "migrate"
+	} else if *purgeFlag {
+		mode = "purge"
+	} else if *exportFlag {
+		mode = "export"
+	} else if *checkFlag {
+		mode = "check"
+	} else if *brokersFlag != "" {
+		mode = "brokers"
+	} else if *downloadFlag {
 		mode = "download"
 	} else if *loadFlag {
 		mode = "load"
+	} else if *watchFlag {
+		mode = "watch"
 	} else if *serveFlag {
 		mode = "serve"
 	}
 
 	starterCfg := starter.StarterConfig{
-		Mode:    mode,
-		CSVPath: cfg.CSVPath,
-		AppPort: cfg.AppPort,
-		DSN:     cfg.DSN(),
+		Mode:                 mode,
+		CSVPath:              cfg.CSVPath,
+		AppPort:              cfg.AppPort,
+		DSN:                  cfg.DSN(),
+		PartitionGranularity: cfg.PartitionGranularity,
+		QuoteMaxRangeDays:    cfg.QuoteMaxRangeDays,
+		TradesMaxPageSize:    cfg.TradesMaxPageSize,
+		ContinuousRollMethod: cfg.ContinuousRollMethod,
+		ContinuousRollDays:   cfg.ContinuousRollDays,
+		BrokersFile:          *brokersFlag,
+		BlockTrades: models.BlockTradeRule{
+			Multiple:    cfg.BlockTradeMultiple,
+			MinQuantity: cfg.BlockTradeMinQuantity,
+			Window:      cfg.BlockTradeWindow,
+		},
+		Watch: ingestion.WatchOptions{
+			PollInterval: cfg.WatchPollInterval,
+			SettleDelay:  cfg.WatchSettleDelay,
+		},
+		Filter: ingestion.FilterConfig{
+			Tickers:  cfg.IngestTickers,
+			Regex:    cfg.IngestTickerRegex,
+			Segments: cfg.IngestSegments,
+		},
+		Retention: retention.Options{
+			Months:         cfg.RetentionMonths,
+			KeepAggregates: cfg.RetentionKeepAggregates,
+			DryRun:         *dryRunFlag,
+		},
+		Quality: quality.Options{
+			TrailingDays: cfg.QualityTrailingDays,
+			RowDeviation: cfg.QualityRowDeviation,
+			PriceJump:    cfg.QualityPriceJump,
+		},
+		Check: starter.CheckConfig{
+			From: *fromFlag,
+			To:   *toFlag,
+			Out:  *outFlag,
+		},
+		Export: starter.ExportConfig{
+			Ticker:  *tickerFlag,
+			From:    *fromFlag,
+			To:      *toFlag,
+			Dataset: *datasetFlag,
+			Format:  *formatFlag,
+			Out:     *outFlag,
+		},
 		DBConfig: database.Config{
 			Name:     cfg.DatabaseName,
 			Host:     cfg.DatabaseHost,
@@ -0,0 +1,140 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultBlockTradesLimit = 100
+	maxBlockTradesLimit     = 1000
+)
+
+// tickerRe restricts ticker and prefix to the characters used by B3 tickers, so user input never
+// carries LIKE wildcards.
+var tickerRe = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+
+type BlockTradeResponse struct {
+	Ticker         string    `json:"ticker"`
+	Date           string    `json:"date"`
+	Time           time.Time `json:"time"`
+	Price          float64   `json:"price"`
+	Quantity       int64     `json:"quantity"`
+	TradeID        int64     `json:"trade_id"`
+	MedianQuantity *float64  `json:"median_quantity"`
+	Reason         string    `json:"reason"`
+}
+
+type BlockTradesResponse struct {
+	Total  int64                `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+	Trades []BlockTradeResponse `json:"trades"`
+}
+
+type BlockTradeService interface {
+	ListBlockTrades(ctx context.Context, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error)
+}
+
+// GetBlockTradesHandler serves the trades flagged as block trades during ingestion. ticker and
+// prefix select instruments, from and to bound the sessions, min_quantity and reason
+// narrow the trades, and limit/offset page through the results.
+func GetBlockTradesHandler(svc BlockTradeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseBlockTradesFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		trades, total, err := svc.ListBlockTrades(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := BlockTradesResponse{
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+			Trades: make([]BlockTradeResponse, 0, len(trades)),
+		}
+		for _, t := range trades {
+			resp.Trades = append(resp.Trades, BlockTradeResponse{
+				Ticker:         t.Ticker,
+				Date:           t.Date.Format("2006-01-02"),
+				Time:           models.SessionTime(t.Date, t.ClosingTime),
+				Price:          t.Price,
+				Quantity:       t.Quantity,
+				TradeID:        t.TradeID,
+				MedianQuantity: t.MedianQuantity,
+				Reason:         t.Reason,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func parseBlockTradesFilter(c *gin.Context) (models.BlockTradeFilter, error) {
+	filter := models.BlockTradeFilter{Limit: defaultBlockTradesLimit}
+	params := map[string]*string{"ticker": &filter.Ticker, "prefix": &filter.Prefix}
+	for param, dst := range params {
+		value := c.Query(param)
+		if !tickerRe.MatchString(value) {
+			return filter, fmt.Errorf("%s must contain only letters and digits", param)
+		}
+		*dst = strings.ToUpper(value)
+	}
+	dates := map[string]**time.Time{"from": &filter.From, "to": &filter.To}
+	for param, dst := range dates {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s, use format YYYY-MM-DD", param)
+		}
+		*dst = &date
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return filter, errors.New("from must not be after to")
+	}
+	if value := c.Query("min_quantity"); value != "" {
+		quantity, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || quantity < 0 {
+			return filter, errors.New("min_quantity must be a non-negative integer")
+		}
+		filter.MinQuantity = quantity
+	}
+	switch reason := c.Query("reason"); reason {
+	case "", models.BlockReasonMedian, models.BlockReasonThreshold:
+		filter.Reason = reason
+	default:
+		return filter, fmt.Errorf("invalid reason %q, use median or threshold", reason)
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxBlockTradesLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxBlockTradesLimit)
+		}
+		filter.Limit = limit
+	}
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return filter, errors.New("offset must be a non-negative integer")
+		}
+		filter.Offset = offset
+	}
+	return filter, nil
+}
@@ -0,0 +1,134 @@
+package blocktrades
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBlockTradeService struct {
+	gotFilter models.BlockTradeFilter
+}
+
+func (m *mockBlockTradeService) ListBlockTrades(ctx context.Context, filter models.BlockTradeFilter) ([]models.BlockTrade, int64, error) {
+	m.gotFilter = filter
+	if filter.Prefix == "FAIL" {
+		return nil, 0, assert.AnError
+	}
+	median := 100.0
+	return []models.BlockTrade{{
+		Ticker: "PETR4",
+		Trade: models.Trade{
+			Date:        time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
+			ClosingTime: 103015250,
+			Price:       32.45,
+			Quantity:    500000,
+			TradeID:     42,
+		},
+		MedianQuantity: &median,
+		Reason:         models.BlockReasonMedian,
+	}}, 5, nil
+}
+
+func newRouter(svc BlockTradeService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/block-trades", GetBlockTradesHandler(svc))
+	return r
+}
+
+func TestGetBlockTradesHandlerGivenFiltersWhenRequestIsMadeThenReturnsPage(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	svc := &mockBlockTradeService{}
+	r := newRouter(svc)
+	req, _ := http.NewRequest("GET", "/v1/block-trades?prefix=petr&from=2025-07-01&to=2025-07-31&min_quantity=1000&reason=median&limit=1&offset=2", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "PETR", svc.gotFilter.Prefix)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), *svc.gotFilter.From)
+	assert.Equal(t, time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), *svc.gotFilter.To)
+	assert.Equal(t, int64(1000), svc.gotFilter.MinQuantity)
+	assert.Equal(t, models.BlockReasonMedian, svc.gotFilter.Reason)
+	var resp BlockTradesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Total)
+	assert.Equal(t, 1, resp.Limit)
+	assert.Equal(t, 2, resp.Offset)
+	assert.Equal(t, "PETR4", resp.Trades[0].Ticker)
+	assert.Equal(t, "2025-07-29", resp.Trades[0].Date)
+	assert.Equal(t, 10, resp.Trades[0].Time.Hour())
+	assert.Equal(t, int64(500000), resp.Trades[0].Quantity)
+	if assert.NotNil(t, resp.Trades[0].MedianQuantity) {
+		assert.Equal(t, 100.0, *resp.Trades[0].MedianQuantity)
+	}
+	assert.Equal(t, models.BlockReasonMedian, resp.Trades[0].Reason)
+}
+
+func TestGetBlockTradesHandlerGivenNoParamsWhenRequestIsMadeThenUsesDefaultLimit(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	svc := &mockBlockTradeService{}
+	r := newRouter(svc)
+	req, _ := http.NewRequest("GET", "/v1/block-trades", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultBlockTradesLimit, svc.gotFilter.Limit)
+	assert.Nil(t, svc.gotFilter.From)
+}
+
+func TestGetBlockTradesHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	cases := []string{
+		"ticker=PETR%25",
+		"from=29-07-2025",
+		"from=2025-07-31&to=2025-07-01",
+		"min_quantity=-1",
+		"reason=huge",
+		"limit=0",
+		"limit=1001",
+		"offset=-1",
+	}
+	for _, query := range cases {
+		t.Run(query, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			r := newRouter(&mockBlockTradeService{})
+			req, _ := http.NewRequest("GET", "/v1/block-trades?"+query, nil)
+
+			// Act
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetBlockTradesHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	r := newRouter(&mockBlockTradeService{})
+	req, _ := http.NewRequest("GET", "/v1/block-trades?prefix=fail", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,119 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of a 404, with the same fields as the other routes.
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// BrokerFlowEntry is what one participant bought and sold. Name is empty for codes missing from
+// the broker mapping; the net fields are bought minus sold.
+type BrokerFlowEntry struct {
+	Code         int     `json:"code"`
+	Name         string  `json:"name"`
+	BuyQuantity  int64   `json:"buy_quantity"`
+	SellQuantity int64   `json:"sell_quantity"`
+	NetQuantity  int64   `json:"net_quantity"`
+	BuyNotional  float64 `json:"buy_notional"`
+	SellNotional float64 `json:"sell_notional"`
+	NetNotional  float64 `json:"net_notional"`
+	BuyTrades    int64   `json:"buy_trades"`
+	SellTrades   int64   `json:"sell_trades"`
+}
+
+type BrokerFlowResponse struct {
+	Ticker     string            `json:"ticker"`
+	DataInicio string            `json:"data_inicio"`
+	DataFim    string            `json:"data_fim"`
+	TopBuyers  []BrokerFlowEntry `json:"top_buyers"`
+	TopSellers []BrokerFlowEntry `json:"top_sellers"`
+	Brokers    []BrokerFlowEntry `json:"brokers"`
+}
+
+// defaultBrokerTop and maxBrokerTop bound the top buyers and sellers of /v1/broker-flow.
+const (
+	defaultBrokerTop = 5
+	maxBrokerTop     = 50
+)
+
+type BrokerFlowService interface {
+	GetBrokerFlow(ctx context.Context, ticker string, startDate, endDate time.Time, top int) (models.BrokerFlowReport, error)
+}
+
+// GetBrokerFlowHandler serves /v1/broker-flow: the quantity and notional each participant bought
+// and sold of a ticker between data_inicio and data_fim, with the top net buyers and sellers.
+// maxRangeDays caps the range, as the flow is read from the raw trades.
+func GetBrokerFlowHandler(svc BrokerFlowService, maxRangeDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticker := c.Query("ticker")
+		if ticker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
+			return
+		}
+		top := defaultBrokerTop
+		if raw := c.Query("top"); raw != "" {
+			var err error
+			top, err = strconv.Atoi(raw)
+			if err != nil || top < 1 || top > maxBrokerTop {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("top must be between 1 and %d", maxBrokerTop)})
+				return
+			}
+		}
+		startDate, endDate, ok := daterange.Parse(c, maxRangeDays)
+		if !ok {
+			return
+		}
+
+		report, err := svc.GetBrokerFlow(c.Request.Context(), ticker, startDate, endDate, top)
+		if errors.Is(err, models.ErrTickerNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "ticker_not_found", Ticker: ticker})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, BrokerFlowResponse{
+			Ticker:     ticker,
+			DataInicio: startDate.Format("2006-01-02"),
+			DataFim:    endDate.Format("2006-01-02"),
+			TopBuyers:  brokerFlowEntries(report.TopBuyers),
+			TopSellers: brokerFlowEntries(report.TopSellers),
+			Brokers:    brokerFlowEntries(report.Brokers),
+		})
+	}
+}
+
+func brokerFlowEntries(flows []models.BrokerFlow) []BrokerFlowEntry {
+	entries := make([]BrokerFlowEntry, 0, len(flows))
+	for _, f := range flows {
+		entries = append(entries, BrokerFlowEntry{
+			Code:         f.Code,
+			Name:         f.Name,
+			BuyQuantity:  f.BuyQuantity,
+			SellQuantity: f.SellQuantity,
+			NetQuantity:  f.NetQuantity(),
+			BuyNotional:  f.BuyNotional,
+			SellNotional: f.SellNotional,
+			NetNotional:  f.NetNotional(),
+			BuyTrades:    f.BuyTrades,
+			SellTrades:   f.SellTrades,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,95 @@
+package brokers
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBrokerFlowService struct {
+	gotTop int
+}
+
+func (m *mockBrokerFlowService) GetBrokerFlow(ctx context.Context, ticker string, startDate, endDate time.Time, top int) (models.BrokerFlowReport, error) {
+	m.gotTop = top
+	switch ticker {
+	case "FAIL":
+		return models.BrokerFlowReport{}, assert.AnError
+	case "UNKNOWN":
+		return models.BrokerFlowReport{}, models.ErrTickerNotFound
+	}
+	flows := []models.BrokerFlow{
+		{Code: 3, Name: "XP INVESTIMENTOS", BuyQuantity: 300, SellQuantity: 50, BuyNotional: 9800, SellNotional: 1550, BuyTrades: 2, SellTrades: 1},
+		{Code: 8, BuyQuantity: 50, SellQuantity: 300, BuyNotional: 1550, SellNotional: 9800, BuyTrades: 1, SellTrades: 2},
+	}
+	return models.NewBrokerFlowReport(flows, top), nil
+}
+
+func TestGetBrokerFlowHandlerGivenTickerWhenRequestIsMadeThenReturnsFlowAndTops(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockBrokerFlowService{}
+	r.GET("/v1/broker-flow", GetBrokerFlowHandler(svc, 366))
+	req, _ := http.NewRequest("GET", "/v1/broker-flow?ticker=PETR4&data_inicio=2025-07-28&data_fim=2025-07-29&top=3", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3, svc.gotTop)
+	var resp BrokerFlowResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-07-28", resp.DataInicio)
+	assert.Len(t, resp.Brokers, 2)
+	assert.Equal(t, "XP INVESTIMENTOS", resp.TopBuyers[0].Name)
+	assert.Equal(t, int64(250), resp.TopBuyers[0].NetQuantity)
+	assert.Equal(t, 8250.0, resp.TopBuyers[0].NetNotional)
+	assert.Equal(t, 8, resp.TopSellers[0].Code)
+	assert.Equal(t, int64(-250), resp.TopSellers[0].NetQuantity)
+}
+
+func TestGetBrokerFlowHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/broker-flow", GetBrokerFlowHandler(&mockBrokerFlowService{}, 31))
+	queries := []string{"", "ticker=PETR4&top=0", "ticker=PETR4&top=51", "ticker=PETR4&data_inicio=2025-01-01&data_fim=2025-07-01"}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/broker-flow?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetBrokerFlowHandlerGivenUnknownTickerWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/broker-flow", GetBrokerFlowHandler(&mockBrokerFlowService{}, 366))
+	req, _ := http.NewRequest("GET", "/v1/broker-flow?ticker=UNKNOWN", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "ticker_not_found")
+}
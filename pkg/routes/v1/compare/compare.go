@@ -0,0 +1,117 @@
+package compare
+
+import (
+	"b3-ingest/internal/domain/comparison"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of a 404, with the same fields as the other routes.
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// ComparisonResponse holds series aligned on Dates. Correlation rows and columns follow Tickers
+// and Spread is the close of the first ticker minus the close of the second. Missing values are null.
+type ComparisonResponse struct {
+	Tickers     []string              `json:"tickers"`
+	Benchmark   string                `json:"benchmark"`
+	Gaps        string                `json:"gaps"`
+	DataInicio  string                `json:"data_inicio"`
+	DataFim     string                `json:"data_fim"`
+	Dates       []string              `json:"dates"`
+	Closes      map[string][]*float64 `json:"closes"`
+	Returns     map[string][]*float64 `json:"returns"`
+	Correlation [][]*float64          `json:"correlation"`
+	Beta        map[string]*float64   `json:"beta"`
+	Spread      []*float64            `json:"spread"`
+}
+
+// maxCompareTickers limits how many tickers a single /v1/compare request may align.
+const maxCompareTickers = 20
+
+type ComparisonService interface {
+	Compare(ctx context.Context, tickers []string, benchmark string, policy comparison.GapPolicy, startDate, endDate time.Time) (comparison.Comparison, error)
+}
+
+// GetCompareHandler serves /v1/compare: the daily closes and returns of two or more tickers aligned
+// on the union of their sessions, their correlation matrix, the beta of each against benchmark
+// (the first ticker by default) and the spread between the first two. gaps selects how sessions
+// without trades are handled: ffill (default), drop or null.
+func GetCompareHandler(svc ComparisonService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tickers := uniqueTickers(strings.Split(c.Query("tickers"), ","))
+		if len(tickers) < 2 || len(tickers) > maxCompareTickers {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("tickers must list between 2 and %d tickers", maxCompareTickers)})
+			return
+		}
+		benchmark := c.DefaultQuery("benchmark", tickers[0])
+		if !slices.Contains(tickers, benchmark) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "benchmark must be one of tickers"})
+			return
+		}
+		policy, err := comparison.ParseGapPolicy(c.Query("gaps"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		startDate, endDate, ok := daterange.Parse(c, 0)
+		if !ok {
+			return
+		}
+
+		cmp, err := svc.Compare(c.Request.Context(), tickers, benchmark, policy, startDate, endDate)
+		if errors.Is(err, models.ErrTickerNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "ticker_not_found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := ComparisonResponse{
+			Tickers:     tickers,
+			Benchmark:   benchmark,
+			Gaps:        string(policy),
+			DataInicio:  startDate.Format("2006-01-02"),
+			DataFim:     endDate.Format("2006-01-02"),
+			Dates:       make([]string, 0, len(cmp.Dates)),
+			Closes:      cmp.Closes,
+			Returns:     cmp.Returns,
+			Correlation: cmp.Correlation,
+			Beta:        cmp.Beta,
+			Spread:      cmp.Spread,
+		}
+		for _, d := range cmp.Dates {
+			resp.Dates = append(resp.Dates, d.Format("2006-01-02"))
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// uniqueTickers trims the tickers and drops blanks and repeats, keeping their order.
+func uniqueTickers(tickers []string) []string {
+	seen := make(map[string]bool, len(tickers))
+	unique := make([]string, 0, len(tickers))
+	for _, t := range tickers {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		unique = append(unique, t)
+	}
+	return unique
+}
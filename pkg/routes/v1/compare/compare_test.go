@@ -0,0 +1,118 @@
+package compare
+
+import (
+	"b3-ingest/internal/domain/comparison"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockComparisonService struct {
+	gotTickers   []string
+	gotBenchmark string
+	gotPolicy    comparison.GapPolicy
+}
+
+func (m *mockComparisonService) Compare(ctx context.Context, tickers []string, benchmark string, policy comparison.GapPolicy, startDate, endDate time.Time) (comparison.Comparison, error) {
+	m.gotTickers, m.gotBenchmark, m.gotPolicy = tickers, benchmark, policy
+	if tickers[0] == "FAIL" {
+		return comparison.Comparison{}, assert.AnError
+	}
+	if tickers[0] == "UNKNOWN" {
+		return comparison.Comparison{}, models.ErrTickerNotFound
+	}
+	observations := map[string][]comparison.Observation{}
+	for i, t := range tickers {
+		observations[t] = []comparison.Observation{
+			{Date: startDate, Close: float64(10 + i)},
+			{Date: endDate, Close: float64(11 + i)},
+		}
+	}
+	return comparison.Compare(tickers, benchmark, policy, observations), nil
+}
+
+func TestGetCompareHandlerGivenTwoTickersWhenRequestIsMadeThenReturnsAlignedSeries(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockComparisonService{}
+	r.GET("/v1/compare", GetCompareHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/compare?tickers=PETR3,PETR4&benchmark=PETR4&gaps=drop&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"PETR3", "PETR4"}, svc.gotTickers)
+	assert.Equal(t, "PETR4", svc.gotBenchmark)
+	assert.Equal(t, comparison.GapDrop, svc.gotPolicy)
+	var resp ComparisonResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2025-07-28", "2025-07-29"}, resp.Dates)
+	assert.Nil(t, resp.Returns["PETR3"][0])
+	assert.InDelta(t, 0.1, *resp.Returns["PETR3"][1], 1e-9)
+	assert.Equal(t, -1.0, *resp.Spread[0])
+	assert.Len(t, resp.Correlation, 2)
+}
+
+func TestGetCompareHandlerGivenNoBenchmarkWhenRequestIsMadeThenUsesFirstTickerAndForwardFill(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockComparisonService{}
+	r.GET("/v1/compare", GetCompareHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/compare?tickers=WDOQ25,DOLQ25", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "WDOQ25", svc.gotBenchmark)
+	assert.Equal(t, comparison.GapForwardFill, svc.gotPolicy)
+}
+
+func TestGetCompareHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/compare", GetCompareHandler(&mockComparisonService{}))
+	queries := []string{"", "tickers=PETR4", "tickers=PETR4,PETR4", "tickers=PETR3,PETR4&benchmark=VALE3", "tickers=PETR3,PETR4&gaps=interpolate"}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/compare?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetCompareHandlerGivenUnknownTickerWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/compare", GetCompareHandler(&mockComparisonService{}))
+	req, _ := http.NewRequest("GET", "/v1/compare?tickers=UNKNOWN,PETR4", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
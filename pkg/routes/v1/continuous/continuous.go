@@ -0,0 +1,115 @@
+package continuous
+
+import (
+	"b3-ingest/internal/domain/futures"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// ContinuousBarResponse is one session of the continuous series; Contract is the code the session
+// was taken from and Rolled marks the first session of a new contract.
+type ContinuousBarResponse struct {
+	Date     string  `json:"date"`
+	Contract string  `json:"contract"`
+	Open     float64 `json:"open"`
+	High     float64 `json:"high"`
+	Low      float64 `json:"low"`
+	Close    float64 `json:"close"`
+	Volume   int64   `json:"volume"`
+	Trades   int64   `json:"trades"`
+	Rolled   bool    `json:"rolled"`
+}
+
+type ContinuousResponse struct {
+	Root       string                  `json:"root"`
+	Roll       string                  `json:"roll"`
+	RollDays   int                     `json:"roll_days"`
+	DataInicio string                  `json:"data_inicio"`
+	DataFim    string                  `json:"data_fim"`
+	Bars       []ContinuousBarResponse `json:"bars"`
+}
+
+type ContinuousService interface {
+	GetContinuous(ctx context.Context, root string, startDate, endDate time.Time, rule futures.RollRule) ([]futures.ContinuousBar, error)
+}
+
+// GetContinuousHandler serves /v1/continuous. roll and roll_days override defaultRule for the
+// request; roll_days only matters with roll=expiry.
+func GetContinuousHandler(svc ContinuousService, defaultRule futures.RollRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		root := strings.ToUpper(c.Query("root"))
+		if root == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "root is required"})
+			return
+		}
+		startDate, endDate, ok := daterange.Parse(c, 0)
+		if !ok {
+			return
+		}
+		rule, err := parseRollRule(c, defaultRule)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		bars, err := svc.GetContinuous(c.Request.Context(), root, startDate, endDate, rule)
+		if errors.Is(err, futures.ErrUnknownRoot) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "root_not_found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := ContinuousResponse{
+			Root:       root,
+			Roll:       string(rule.Method),
+			RollDays:   rule.DaysBeforeExpiry,
+			DataInicio: startDate.Format("2006-01-02"),
+			DataFim:    endDate.Format("2006-01-02"),
+			Bars:       make([]ContinuousBarResponse, 0, len(bars)),
+		}
+		for _, b := range bars {
+			resp.Bars = append(resp.Bars, ContinuousBarResponse{
+				Date:     b.Date.Format("2006-01-02"),
+				Contract: b.Contract.Code,
+				Open:     b.Open,
+				High:     b.High,
+				Low:      b.Low,
+				Close:    b.Close,
+				Volume:   b.Volume,
+				Trades:   b.Trades,
+				Rolled:   b.Rolled,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func parseRollRule(c *gin.Context, defaultRule futures.RollRule) (futures.RollRule, error) {
+	method := c.DefaultQuery("roll", string(defaultRule.Method))
+	days := defaultRule.DaysBeforeExpiry
+	if raw := c.Query("roll_days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return futures.RollRule{}, fmt.Errorf("invalid roll_days %q", raw)
+		}
+		days = n
+	}
+	return futures.ParseRollRule(method, days)
+}
@@ -0,0 +1,110 @@
+package continuous
+
+import (
+	"b3-ingest/internal/domain/futures"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockContinuousService struct {
+	gotRollRule futures.RollRule
+}
+
+func (m *mockContinuousService) GetContinuous(ctx context.Context, root string, startDate, endDate time.Time, rule futures.RollRule) ([]futures.ContinuousBar, error) {
+	m.gotRollRule = rule
+	switch root {
+	case "FAIL":
+		return nil, assert.AnError
+	case "XYZ":
+		return nil, futures.ErrUnknownRoot
+	}
+	q25, _ := futures.ParseContract(root + "Q25")
+	u25, _ := futures.ParseContract(root + "U25")
+	return []futures.ContinuousBar{
+		{Bar: futures.Bar{Contract: q25, Date: startDate, Close: 5500, Volume: 100}},
+		{Bar: futures.Bar{Contract: u25, Date: endDate, Close: 5530, Volume: 120}, Rolled: true},
+	}, nil
+}
+
+func TestGetContinuousHandlerGivenRootWhenRequestIsMadeThenReturnsSeriesWithDefaultRule(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockContinuousService{}
+	r.GET("/v1/continuous", GetContinuousHandler(svc, futures.RollRule{Method: futures.RollOnVolume}))
+	req, _ := http.NewRequest("GET", "/v1/continuous?root=wdo&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ContinuousResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "WDO", resp.Root)
+	assert.Equal(t, "volume", resp.Roll)
+	assert.Len(t, resp.Bars, 2)
+	assert.Equal(t, "WDOQ25", resp.Bars[0].Contract)
+	assert.True(t, resp.Bars[1].Rolled)
+	assert.Equal(t, "2025-07-29", resp.Bars[1].Date)
+}
+
+func TestGetContinuousHandlerGivenExpiryRollWhenRequestIsMadeThenPassesRule(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockContinuousService{}
+	r.GET("/v1/continuous", GetContinuousHandler(svc, futures.RollRule{Method: futures.RollOnVolume}))
+	req, _ := http.NewRequest("GET", "/v1/continuous?root=WDO&data_inicio=2025-07-28&data_fim=2025-07-29&roll=expiry&roll_days=3", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, futures.RollRule{Method: futures.RollBeforeExpiry, DaysBeforeExpiry: 3}, svc.gotRollRule)
+}
+
+func TestGetContinuousHandlerGivenInvalidRollWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/continuous", GetContinuousHandler(&mockContinuousService{}, futures.RollRule{Method: futures.RollOnVolume}))
+	req, _ := http.NewRequest("GET", "/v1/continuous?root=WDO&data_inicio=2025-07-28&data_fim=2025-07-29&roll=open_interest", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetContinuousHandlerGivenUnknownRootWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/continuous", GetContinuousHandler(&mockContinuousService{}, futures.RollRule{Method: futures.RollOnVolume}))
+	req, _ := http.NewRequest("GET", "/v1/continuous?root=XYZ&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var resp ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "root_not_found", resp.Code)
+}
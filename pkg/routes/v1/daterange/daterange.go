@@ -0,0 +1,59 @@
+// Package daterange reads the data_inicio and data_fim parameters shared by the v1 routes.
+package daterange
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultDays is how far before data_fim the range starts when data_inicio is missing.
+const DefaultDays = 7
+
+// Parse reads data_inicio and data_fim from the query string. It writes a 400 response and
+// returns false when the range is invalid.
+func Parse(c *gin.Context, maxDays int) (time.Time, time.Time, bool) {
+	startDate, endDate, err := Resolve(c.Query("data_inicio"), c.Query("data_fim"), maxDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return time.Time{}, time.Time{}, false
+	}
+	return startDate, endDate, true
+}
+
+// Resolve parses data_inicio and data_fim (YYYY-MM-DD). data_fim defaults to today and
+// data_inicio to DefaultDays before data_fim, or later when maxDays is smaller. When maxDays is
+// positive the range may span at most that many days.
+func Resolve(dataInicio, dataFim string, maxDays int) (time.Time, time.Time, error) {
+	now := time.Now()
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var err error
+
+	if dataFim != "" {
+		endDate, err = time.Parse("2006-01-02", dataFim)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("data_fim inválida, use formato YYYY-MM-DD")
+		}
+	}
+	defaultDays := DefaultDays
+	if maxDays > 0 && defaultDays > maxDays-1 {
+		defaultDays = maxDays - 1
+	}
+	startDate := endDate.AddDate(0, 0, -defaultDays)
+	if dataInicio != "" {
+		startDate, err = time.Parse("2006-01-02", dataInicio)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("data_inicio inválida, use formato YYYY-MM-DD")
+		}
+	}
+	if startDate.After(endDate) {
+		return time.Time{}, time.Time{}, errors.New("data_inicio must not be after data_fim")
+	}
+	if maxDays > 0 && endDate.Sub(startDate) >= time.Duration(maxDays)*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("range between data_inicio and data_fim must not exceed %d days", maxDays)
+	}
+	return startDate, endDate, nil
+}
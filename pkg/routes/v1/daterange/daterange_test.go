@@ -0,0 +1,49 @@
+package daterange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveGivenOnlyDataFimWhenResolvedThenStartsSevenDaysBefore(t *testing.T) {
+	// Act
+	startDate, endDate, err := Resolve("", "2025-07-10", 30)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC), startDate)
+	assert.Equal(t, time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), endDate)
+}
+
+func TestResolveGivenSmallCapWhenNoDataInicioThenClampsDefaultStart(t *testing.T) {
+	// Arrange
+	for _, maxDays := range []int{1, 3, 7} {
+		// Act
+		start, end, err := Resolve("", "2025-07-10", maxDays)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC), end)
+		assert.Equal(t, end.AddDate(0, 0, -(maxDays-1)), start)
+	}
+}
+
+func TestResolveGivenInvalidRangesWhenResolvedThenReturnsError(t *testing.T) {
+	// Arrange
+	cases := [][2]string{
+		{"2025-07-10", "2025-07-01"},
+		{"2025-07-01", "2025-07-31"},
+		{"invalid", "2025-07-01"},
+		{"", "invalid"},
+	}
+
+	for _, dates := range cases {
+		// Act
+		_, _, err := Resolve(dates[0], dates[1], 30)
+
+		// Assert
+		assert.Error(t, err, dates)
+	}
+}
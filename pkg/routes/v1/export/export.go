@@ -0,0 +1,41 @@
+package export
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, w io.Writer, req models.ExportRequest) (int64, error)
+}
+
+// GetExportHandler streams the trades or daily bars of a ticker between from and to as an
+// attachment in CSV, NDJSON or Parquet. Rows are written while they are read from the database,
+// so once the body has started an error can only cut the download short.
+func GetExportHandler(svc ExportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := models.NewExportRequest(c.Query("ticker"), c.Query("from"), c.Query("to"), c.Query("dataset"), c.Query("format"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Header("Content-Type", req.Format.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, req.FileName()))
+		if _, err := svc.Export(c.Request.Context(), c.Writer, req); err != nil {
+			if !c.Writer.Written() {
+				c.Writer.Header().Del("Content-Type")
+				c.Writer.Header().Del("Content-Disposition")
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.Error(err)
+			c.Abort()
+		}
+	}
+}
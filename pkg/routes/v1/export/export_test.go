@@ -0,0 +1,89 @@
+package export
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockExportService struct {
+	gotRequest models.ExportRequest
+}
+
+func (m *mockExportService) Export(ctx context.Context, w io.Writer, req models.ExportRequest) (int64, error) {
+	m.gotRequest = req
+	if req.Ticker == "FAIL" {
+		return 0, assert.AnError
+	}
+	_, err := io.WriteString(w, "date,close\n2025-07-29,30.5\n")
+	return 1, err
+}
+
+func TestGetExportHandlerGivenValidParamsWhenRequestIsMadeThenStreamsAttachment(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockExportService{}
+	r := gin.Default()
+	r.GET("/v1/export", GetExportHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/export?ticker=PETR4&from=2025-07-01&to=2025-07-31", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.ExportDailyBars, svc.gotRequest.Dataset)
+	assert.Equal(t, models.ExportCSV, svc.gotRequest.Format)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="PETR4_daily_2025-07-01_2025-07-31.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "date,close\n2025-07-29,30.5\n", w.Body.String())
+}
+
+func TestGetExportHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/export", GetExportHandler(&mockExportService{}))
+	queries := []string{
+		"from=2025-07-01&to=2025-07-31",
+		"ticker=PETR4&from=2025-07-01&to=2025-07-31&format=xlsx",
+		"ticker=PETR4&from=2025-07-01&to=2025-07-31&dataset=quotes",
+		"ticker=PETR4&to=2025-07-31",
+		"ticker=PETR4&from=2025-08-01&to=2025-07-31",
+	}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/export?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetExportHandlerGivenServiceErrorBeforeAnyRowWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/export", GetExportHandler(&mockExportService{}))
+	req, _ := http.NewRequest("GET", "/v1/export?ticker=FAIL&from=2025-07-01&to=2025-07-31&format=parquet", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
@@ -0,0 +1,103 @@
+package indicators
+
+import (
+	"b3-ingest/internal/domain/indicators"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of a 404, with the same fields as the other routes.
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// IndicatorPointResponse is one session of an indicator. Value is null while the window is not
+// full; upper and lower are only set for Bollinger bands, whose middle band is value.
+type IndicatorPointResponse struct {
+	Date  string   `json:"date"`
+	Value *float64 `json:"value"`
+	Upper *float64 `json:"upper,omitempty"`
+	Lower *float64 `json:"lower,omitempty"`
+}
+
+type IndicatorResponse struct {
+	Ticker     string                   `json:"ticker"`
+	Indicator  string                   `json:"indicator"`
+	Window     int                      `json:"window"`
+	DataInicio string                   `json:"data_inicio"`
+	DataFim    string                   `json:"data_fim"`
+	Points     []IndicatorPointResponse `json:"points"`
+}
+
+// maxIndicatorWindow bounds the window of /v1/indicators, in sessions.
+const maxIndicatorWindow = 250
+
+type IndicatorService interface {
+	GetIndicator(ctx context.Context, ticker string, kind indicators.Kind, window int, startDate, endDate time.Time) ([]indicators.Point, error)
+}
+
+// GetIndicatorsHandler serves /v1/indicators: sma, ema, rsi, bollinger, atr, volatility or adv over
+// the daily bars of a ticker, with window in sessions defaulting per indicator.
+func GetIndicatorsHandler(svc IndicatorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticker := c.Query("ticker")
+		if ticker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
+			return
+		}
+		kind, window, err := indicators.ParseKind(c.Query("indicator"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if raw := c.Query("window"); raw != "" {
+			window, err = strconv.Atoi(raw)
+			if err != nil || window < 2 || window > maxIndicatorWindow {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("window must be between 2 and %d", maxIndicatorWindow)})
+				return
+			}
+		}
+		startDate, endDate, ok := daterange.Parse(c, 0)
+		if !ok {
+			return
+		}
+
+		points, err := svc.GetIndicator(c.Request.Context(), ticker, kind, window, startDate, endDate)
+		if errors.Is(err, models.ErrTickerNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "ticker_not_found", Ticker: ticker})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := IndicatorResponse{
+			Ticker:     ticker,
+			Indicator:  string(kind),
+			Window:     window,
+			DataInicio: startDate.Format("2006-01-02"),
+			DataFim:    endDate.Format("2006-01-02"),
+			Points:     make([]IndicatorPointResponse, 0, len(points)),
+		}
+		for _, p := range points {
+			resp.Points = append(resp.Points, IndicatorPointResponse{
+				Date:  p.Date.Format("2006-01-02"),
+				Value: p.Value,
+				Upper: p.Upper,
+				Lower: p.Lower,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
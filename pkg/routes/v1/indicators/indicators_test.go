@@ -0,0 +1,112 @@
+package indicators
+
+import (
+	"b3-ingest/internal/domain/indicators"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIndicatorService struct {
+	gotKind   indicators.Kind
+	gotWindow int
+}
+
+func (m *mockIndicatorService) GetIndicator(ctx context.Context, ticker string, kind indicators.Kind, window int, startDate, endDate time.Time) ([]indicators.Point, error) {
+	m.gotKind, m.gotWindow = kind, window
+	switch ticker {
+	case "FAIL":
+		return nil, assert.AnError
+	case "UNKNOWN":
+		return nil, models.ErrTickerNotFound
+	}
+	middle, upper, lower := 32.0, 33.0, 31.0
+	return []indicators.Point{
+		{Date: startDate},
+		{Date: endDate, Value: &middle, Upper: &upper, Lower: &lower},
+	}, nil
+}
+
+func TestGetIndicatorsHandlerGivenBollingerWhenRequestIsMadeThenReturnsBands(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockIndicatorService{}
+	r.GET("/v1/indicators", GetIndicatorsHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/indicators?ticker=PETR4&indicator=bollinger&window=10&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, indicators.Bollinger, svc.gotKind)
+	assert.Equal(t, 10, svc.gotWindow)
+	var resp IndicatorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "bollinger", resp.Indicator)
+	assert.Len(t, resp.Points, 2)
+	assert.Nil(t, resp.Points[0].Value)
+	assert.Equal(t, 33.0, *resp.Points[1].Upper)
+	assert.Contains(t, w.Body.String(), `{"date":"2025-07-28","value":null}`)
+}
+
+func TestGetIndicatorsHandlerGivenNoWindowWhenRequestIsMadeThenUsesIndicatorDefault(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockIndicatorService{}
+	r.GET("/v1/indicators", GetIndicatorsHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/indicators?ticker=PETR4&indicator=rsi", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 14, svc.gotWindow)
+}
+
+func TestGetIndicatorsHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/indicators", GetIndicatorsHandler(&mockIndicatorService{}))
+	queries := []string{"indicator=sma", "ticker=PETR4", "ticker=PETR4&indicator=macd", "ticker=PETR4&indicator=sma&window=1", "ticker=PETR4&indicator=sma&window=1000"}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/indicators?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetIndicatorsHandlerGivenUnknownTickerWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/indicators", GetIndicatorsHandler(&mockIndicatorService{}))
+	req, _ := http.NewRequest("GET", "/v1/indicators?ticker=UNKNOWN&indicator=sma", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
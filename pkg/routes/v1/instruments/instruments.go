@@ -0,0 +1,141 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultTickersLimit = 100
+	maxTickersLimit     = 1000
+)
+
+// searchRe restricts searches to the characters used by B3 tickers, so user input never carries
+// LIKE wildcards.
+var searchRe = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+
+type TickerResponse struct {
+	Ticker         string `json:"ticker"`
+	FirstTradeDate string `json:"first_trade_date"`
+	LastTradeDate  string `json:"last_trade_date"`
+	TotalTrades    int64  `json:"total_trades"`
+	AssetClass     string `json:"asset_class,omitempty"`
+	Root           string `json:"root,omitempty"`
+	OptionType     string `json:"option_type,omitempty"`
+	OptionSeries   string `json:"option_series,omitempty"`
+	StrikeCode     string `json:"strike_code,omitempty"`
+	ContractMonth  int    `json:"contract_month,omitempty"`
+	ContractYear   int    `json:"contract_year,omitempty"`
+}
+
+type TickersResponse struct {
+	Total   int64            `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+	Tickers []TickerResponse `json:"tickers"`
+}
+
+type InstrumentService interface {
+	ListInstruments(ctx context.Context, filter models.InstrumentFilter) ([]models.Instrument, int64, error)
+}
+
+// GetTickersHandler serves the ticker catalog. prefix and q search by ticker start and substring,
+// asset_class and root select by classification, the first_trade_* and last_trade_* dates bound the first and last sessions, and limit/offset page
+// through the results.
+func GetTickersHandler(svc InstrumentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseTickersFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		instruments, total, err := svc.ListInstruments(c.Request.Context(), filter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := TickersResponse{
+			Total:   total,
+			Limit:   filter.Limit,
+			Offset:  filter.Offset,
+			Tickers: make([]TickerResponse, 0, len(instruments)),
+		}
+		for _, in := range instruments {
+			resp.Tickers = append(resp.Tickers, TickerResponse{
+				Ticker:         in.Ticker,
+				FirstTradeDate: in.FirstTradeDate.Format("2006-01-02"),
+				LastTradeDate:  in.LastTradeDate.Format("2006-01-02"),
+				TotalTrades:    in.TotalTrades,
+				AssetClass:     string(in.Class.AssetClass),
+				Root:           in.Class.Root,
+				OptionType:     in.Class.OptionType,
+				OptionSeries:   in.Class.OptionSeries,
+				StrikeCode:     in.Class.StrikeCode,
+				ContractMonth:  int(in.Class.Month),
+				ContractYear:   in.Class.Year,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func parseTickersFilter(c *gin.Context) (models.InstrumentFilter, error) {
+	filter := models.InstrumentFilter{Limit: defaultTickersLimit}
+	params := map[string]*string{"prefix": &filter.Prefix, "q": &filter.Contains, "root": &filter.Root}
+	for param, dst := range params {
+		value := c.Query(param)
+		if !searchRe.MatchString(value) {
+			return filter, fmt.Errorf("%s must contain only letters and digits", param)
+		}
+		*dst = strings.ToUpper(value)
+	}
+	if value := c.Query("asset_class"); value != "" {
+		if !classifier.ValidAssetClass(value) {
+			return filter, fmt.Errorf("invalid asset_class %q", value)
+		}
+		filter.AssetClass = value
+	}
+	dates := map[string]**time.Time{
+		"first_trade_from": &filter.FirstTradeFrom,
+		"first_trade_to":   &filter.FirstTradeTo,
+		"last_trade_from":  &filter.LastTradeFrom,
+		"last_trade_to":    &filter.LastTradeTo,
+	}
+	for param, dst := range dates {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return filter, fmt.Errorf("%s inválida, use formato YYYY-MM-DD", param)
+		}
+		*dst = &date
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxTickersLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxTickersLimit)
+		}
+		filter.Limit = limit
+	}
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return filter, fmt.Errorf("offset must be a non-negative integer")
+		}
+		filter.Offset = offset
+	}
+	return filter, nil
+}
@@ -0,0 +1,137 @@
+package instruments
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInstrumentService struct {
+	gotFilter models.InstrumentFilter
+}
+
+func (m *mockInstrumentService) ListInstruments(ctx context.Context, filter models.InstrumentFilter) ([]models.Instrument, int64, error) {
+	m.gotFilter = filter
+	if filter.Prefix == "FAIL" {
+		return nil, 0, assert.AnError
+	}
+	return []models.Instrument{{
+		Ticker:         "PETR4",
+		FirstTradeDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		LastTradeDate:  time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC),
+		TotalTrades:    1234,
+		Class:          classifier.Classify("PETR4"),
+	}}, 3, nil
+}
+
+func TestGetTickersHandlerGivenFiltersWhenRequestIsMadeThenReturnsPage(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockInstrumentService{}
+	r := gin.Default()
+	r.GET("/v1/tickers", GetTickersHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/tickers?prefix=petr&last_trade_from=2025-07-20&limit=1&offset=1", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "PETR", svc.gotFilter.Prefix)
+	assert.Equal(t, time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), *svc.gotFilter.LastTradeFrom)
+	assert.Nil(t, svc.gotFilter.FirstTradeFrom)
+	var resp TickersResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+	assert.Equal(t, 1, resp.Limit)
+	assert.Equal(t, 1, resp.Offset)
+	assert.Equal(t, "PETR4", resp.Tickers[0].Ticker)
+	assert.Equal(t, "2025-07-01", resp.Tickers[0].FirstTradeDate)
+	assert.Equal(t, int64(1234), resp.Tickers[0].TotalTrades)
+}
+
+func TestGetTickersHandlerGivenNoParamsWhenRequestIsMadeThenUsesDefaultLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockInstrumentService{}
+	r := gin.Default()
+	r.GET("/v1/tickers", GetTickersHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/tickers", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultTickersLimit, svc.gotFilter.Limit)
+	assert.Equal(t, 0, svc.gotFilter.Offset)
+}
+
+func TestGetTickersHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/tickers", GetTickersHandler(&mockInstrumentService{}))
+	queries := []string{"q=PE%25", "first_trade_to=invalid", "limit=0", "limit=5000", "offset=-1", "asset_class=crypto", "root=WD_"}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/tickers?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetTickersHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/tickers", GetTickersHandler(&mockInstrumentService{}))
+	req, _ := http.NewRequest("GET", "/v1/tickers?prefix=FAIL", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetTickersHandlerGivenClassificationFiltersWhenRequestIsMadeThenPassesThemAndReturnsClass(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockInstrumentService{}
+	r := gin.Default()
+	r.GET("/v1/tickers", GetTickersHandler(svc))
+	req, _ := http.NewRequest("GET", "/v1/tickers?asset_class=equity&root=petr", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "equity", svc.gotFilter.AssetClass)
+	assert.Equal(t, "PETR", svc.gotFilter.Root)
+	var resp TickersResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "equity", resp.Tickers[0].AssetClass)
+	assert.Equal(t, "PETR", resp.Tickers[0].Root)
+	assert.Empty(t, resp.Tickers[0].OptionType)
+}
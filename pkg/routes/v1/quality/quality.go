@@ -0,0 +1,32 @@
+package quality
+
+import (
+	"b3-ingest/internal/domain/quality"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QualityService interface {
+	Check(ctx context.Context, startDate, endDate time.Time) (quality.Report, error)
+}
+
+// GetQualityHandler serves /v1/quality: the data-quality report of the sessions between
+// data_inicio and data_fim, in the JSON form of quality.Report. maxRangeDays caps the range.
+func GetQualityHandler(svc QualityService, maxRangeDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startDate, endDate, ok := daterange.Parse(c, maxRangeDays)
+		if !ok {
+			return
+		}
+		report, err := svc.Check(c.Request.Context(), startDate, endDate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
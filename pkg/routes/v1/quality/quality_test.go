@@ -0,0 +1,74 @@
+package quality
+
+import (
+	"b3-ingest/internal/domain/quality"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockQualityService struct {
+	err error
+}
+
+func (m *mockQualityService) Check(ctx context.Context, startDate, endDate time.Time) (quality.Report, error) {
+	if m.err != nil {
+		return quality.Report{}, m.err
+	}
+	opts := quality.Options{TrailingDays: 5, RowDeviation: 0.5, PriceJump: 0.3}
+	jumps := []quality.PriceJump{{Ticker: "PETR4", Date: endDate, PreviousClose: 32, Close: 16, Change: -0.5}}
+	return quality.Check(startDate, endDate, opts, []quality.DayCount{{Date: startDate, Rows: 10}}, jumps, nil), nil
+}
+
+func TestGetQualityHandlerGivenRangeWhenRequestIsMadeThenReturnsReport(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/quality", GetQualityHandler(&mockQualityService{}, 366))
+	req, _ := http.NewRequest("GET", "/v1/quality?data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, false, resp["ok"])
+	assert.Equal(t, 2.0, resp["expected_days"])
+	assert.Equal(t, 1.0, resp["loaded_days"])
+	assert.Equal(t, []interface{}{"2025-07-29"}, resp["missing_dates"])
+	assert.Contains(t, w.Body.String(), `"duplicate_trades":[]`)
+}
+
+func TestGetQualityHandlerGivenErrorsWhenRequestIsMadeThenReturnsErrorStatus(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/quality", GetQualityHandler(&mockQualityService{}, 31))
+	r.GET("/v1/quality-fail", GetQualityHandler(&mockQualityService{err: assert.AnError}, 31))
+	cases := map[string]int{
+		"/v1/quality?data_inicio=2025-01-01&data_fim=2025-07-01": http.StatusBadRequest,
+		"/v1/quality?data_inicio=2025-07-29&data_fim=2025-07-28": http.StatusBadRequest,
+		"/v1/quality-fail": http.StatusInternalServerError,
+	}
+
+	for url, status := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, status, w.Code, url)
+	}
+}
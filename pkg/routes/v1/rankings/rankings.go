@@ -0,0 +1,125 @@
+package rankings
+
+import (
+	"b3-ingest/internal/domain/classifier"
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RankingEntryResponse struct {
+	Ticker string  `json:"ticker"`
+	Value  float64 `json:"value"`
+	Volume int64   `json:"volume"`
+	Trades int64   `json:"trades"`
+	Close  float64 `json:"close"`
+}
+
+type RankingsResponse struct {
+	Metric     string                 `json:"metric"`
+	DataInicio string                 `json:"data_inicio"`
+	DataFim    string                 `json:"data_fim"`
+	Limit      int                    `json:"limit"`
+	Top        []RankingEntryResponse `json:"top"`
+	Bottom     []RankingEntryResponse `json:"bottom"`
+}
+
+const (
+	defaultRankingsLimit = 10
+	maxRankingsLimit     = 100
+)
+
+// prefixRe restricts ticker prefixes to the characters used by B3 tickers, so user input never
+// carries LIKE wildcards.
+var prefixRe = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+
+type RankingService interface {
+	GetRankings(ctx context.Context, query models.RankingQuery) (models.Rankings, error)
+}
+
+// GetRankingsHandler serves /v1/rankings: the limit instruments with the highest and lowest volume,
+// trades, return or range on date, or between data_inicio and data_fim when date is not given.
+// asset_class and prefix restrict the instruments ranked.
+func GetRankingsHandler(svc RankingService, maxRangeDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, err := parseRankingQuery(c, maxRangeDays)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rankings, err := svc.GetRankings(c.Request.Context(), query)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, RankingsResponse{
+			Metric:     string(query.Metric),
+			DataInicio: query.From.Format("2006-01-02"),
+			DataFim:    query.To.Format("2006-01-02"),
+			Limit:      query.Limit,
+			Top:        newRankingEntries(rankings.Top),
+			Bottom:     newRankingEntries(rankings.Bottom),
+		})
+	}
+}
+
+func parseRankingQuery(c *gin.Context, maxRangeDays int) (models.RankingQuery, error) {
+	query := models.RankingQuery{
+		Metric: models.RankingMetric(c.DefaultQuery("metric", string(models.RankByVolume))),
+		Limit:  defaultRankingsLimit,
+	}
+	if !query.Metric.Valid() {
+		return query, fmt.Errorf("invalid metric %q, use volume, trades, return or range", query.Metric)
+	}
+	var err error
+	if date := c.Query("date"); date != "" {
+		query.From, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return query, errors.New("invalid date, use format YYYY-MM-DD")
+		}
+		query.To = query.From
+	} else {
+		query.From, query.To, err = daterange.Resolve(c.Query("data_inicio"), c.Query("data_fim"), maxRangeDays)
+		if err != nil {
+			return query, err
+		}
+	}
+	if value := c.Query("asset_class"); value != "" {
+		if !classifier.ValidAssetClass(value) {
+			return query, fmt.Errorf("invalid asset_class %q", value)
+		}
+		query.AssetClass = value
+	}
+	prefix := c.Query("prefix")
+	if !prefixRe.MatchString(prefix) {
+		return query, fmt.Errorf("prefix must contain only letters and digits")
+	}
+	query.Prefix = strings.ToUpper(prefix)
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxRankingsLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxRankingsLimit)
+		}
+		query.Limit = limit
+	}
+	return query, nil
+}
+
+func newRankingEntries(entries []models.RankingEntry) []RankingEntryResponse {
+	resp := make([]RankingEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, RankingEntryResponse{Ticker: e.Ticker, Value: e.Value, Volume: e.Volume, Trades: e.Trades, Close: e.Close})
+	}
+	return resp
+}
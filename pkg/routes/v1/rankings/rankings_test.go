@@ -0,0 +1,112 @@
+package rankings
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRankingService struct {
+	gotRanking models.RankingQuery
+}
+
+func (m *mockRankingService) GetRankings(ctx context.Context, query models.RankingQuery) (models.Rankings, error) {
+	m.gotRanking = query
+	if query.Prefix == "FAIL" {
+		return models.Rankings{}, assert.AnError
+	}
+	return models.Rankings{
+		Top:    []models.RankingEntry{{Ticker: "PETR4", Value: 0.1, Volume: 500, Trades: 50, Close: 33}},
+		Bottom: []models.RankingEntry{{Ticker: "VALE3", Value: -0.1, Volume: 900, Trades: 20, Close: 45}},
+	}, nil
+}
+
+func TestGetRankingsHandlerGivenDateAndFiltersWhenRequestIsMadeThenReturnsTopAndBottom(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockRankingService{}
+	r.GET("/v1/rankings", GetRankingsHandler(svc, 366))
+	req, _ := http.NewRequest("GET", "/v1/rankings?date=2025-07-28&metric=return&asset_class=equity&prefix=petr&limit=5", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	day := time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, models.RankingQuery{
+		Metric: models.RankByReturn, From: day, To: day, AssetClass: "equity", Prefix: "PETR", Limit: 5,
+	}, svc.gotRanking)
+	var resp RankingsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "return", resp.Metric)
+	assert.Equal(t, "2025-07-28", resp.DataFim)
+	assert.Equal(t, "PETR4", resp.Top[0].Ticker)
+	assert.Equal(t, -0.1, resp.Bottom[0].Value)
+}
+
+func TestGetRankingsHandlerGivenRangeWhenRequestIsMadeThenDefaultsToVolume(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockRankingService{}
+	r.GET("/v1/rankings", GetRankingsHandler(svc, 366))
+	req, _ := http.NewRequest("GET", "/v1/rankings?data_inicio=2025-07-01&data_fim=2025-07-31", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.RankByVolume, svc.gotRanking.Metric)
+	assert.Equal(t, defaultRankingsLimit, svc.gotRanking.Limit)
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), svc.gotRanking.From)
+}
+
+func TestGetRankingsHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/rankings", GetRankingsHandler(&mockRankingService{}, 30))
+	queries := []string{
+		"metric=spread", "date=28-07-2025", "asset_class=crypto", "prefix=PE%25", "limit=0", "limit=101",
+		"data_inicio=2025-01-01&data_fim=2025-07-31",
+	}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/rankings?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetRankingsHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/rankings", GetRankingsHandler(&mockRankingService{}, 366))
+	req, _ := http.NewRequest("GET", "/v1/rankings?prefix=FAIL", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
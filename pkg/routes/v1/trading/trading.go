@@ -1,57 +1,460 @@
 package trading
 
 import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// QuoteResponse keeps the original ticker, max_range_value and max_daily_volume fields and adds
+// the remaining statistics of the range. A known ticker without trades in the range is answered
+// with empty set to true, zero sessions and the dates omitted.
 type QuoteResponse struct {
 	Ticker         string  `json:"ticker"`
+	Empty          bool    `json:"empty"`
+	Sessions       int64   `json:"sessions"`
 	MaxRangeValue  float64 `json:"max_range_value"`
 	MaxDailyVolume int64   `json:"max_daily_volume"`
+	MinRangeValue  float64 `json:"min_range_value"`
+	OpenPrice      float64 `json:"open_price"`
+	ClosePrice     float64 `json:"close_price"`
+	VWAP           float64 `json:"vwap"`
+	TotalVolume    int64   `json:"total_volume"`
+	TradeCount     int64   `json:"trade_count"`
+	MaxValueDate   string  `json:"max_value_date,omitempty"`
+	MinValueDate   string  `json:"min_value_date,omitempty"`
+	DataInicio     string  `json:"data_inicio"`
+	DataFim        string  `json:"data_fim"`
+}
+
+// ErrorResponse is the body of a 404. Error keeps the message under the same key used by the
+// other error responses, Code identifies the failure for clients.
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+// BatchQuotesRequest is the body of POST /v1/quotes.
+type BatchQuotesRequest struct {
+	Tickers    []string `json:"tickers"`
+	DataInicio string   `json:"data_inicio"`
+	DataFim    string   `json:"data_fim"`
+}
+
+// BatchQuoteResult is the quote of one requested ticker. A known ticker without trades in the
+// range is found with an empty quote; a ticker that was never traded has Found false, Quote
+// omitted and the same error and code as a 404 from /quote.
+type BatchQuoteResult struct {
+	Ticker string         `json:"ticker"`
+	Found  bool           `json:"found"`
+	Error  string         `json:"error,omitempty"`
+	Code   string         `json:"code,omitempty"`
+	Quote  *QuoteResponse `json:"quote,omitempty"`
+}
+
+type BatchQuotesResponse struct {
+	DataInicio string             `json:"data_inicio"`
+	DataFim    string             `json:"data_fim"`
+	Quotes     []BatchQuoteResult `json:"quotes"`
+}
+
+type TradeResponse struct {
+	Date     string    `json:"date"`
+	Time     time.Time `json:"time"`
+	Price    float64   `json:"price"`
+	Quantity int64     `json:"quantity"`
+	TradeID  int64     `json:"trade_id"`
+}
+
+// TradesResponse is a page of trades. NextCursor is omitted on the last page.
+type TradesResponse struct {
+	Ticker     string          `json:"ticker"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Trades     []TradeResponse `json:"trades"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type CandleResponse struct {
+	Start  time.Time `json:"start"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume int64     `json:"volume"`
+	Trades int64     `json:"trades"`
+}
+
+type CandlesResponse struct {
+	Ticker   string           `json:"ticker"`
+	Date     string           `json:"date"`
+	Interval string           `json:"interval"`
+	Candles  []CandleResponse `json:"candles"`
+}
+
+type DailySummaryResponse struct {
+	Date   string   `json:"date"`
+	Open   float64  `json:"open"`
+	High   float64  `json:"high"`
+	Low    float64  `json:"low"`
+	Close  float64  `json:"close"`
+	Volume int64    `json:"volume"`
+	Trades int64    `json:"trades"`
+	VWAP   float64  `json:"vwap"`
+	Return *float64 `json:"daily_return"`
+}
+
+type DailySummariesResponse struct {
+	Ticker     string                 `json:"ticker"`
+	DataInicio string                 `json:"data_inicio"`
+	DataFim    string                 `json:"data_fim"`
+	Days       []DailySummaryResponse `json:"days"`
+}
+
+// defaultTradesPageSize is the page size of /v1/trades when no limit is given.
+const defaultTradesPageSize = 100
+
+// maxBatchTickers limits how many tickers a single POST /v1/quotes may ask for.
+const maxBatchTickers = 500
+
+// candleIntervals are the bucket sizes accepted by the candles endpoint.
+var candleIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
 }
 
 type TradingService interface {
-	GetQuote(ctx context.Context, ticker string, startDate time.Time) (maxPrice float64, maxVol int64, err error)
+	GetQuote(ctx context.Context, ticker string, startDate, endDate time.Time) (models.Quote, error)
+	GetQuotes(ctx context.Context, tickers []string, startDate, endDate time.Time) (map[string]models.Quote, error)
+	GetCandles(ctx context.Context, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error)
+	GetDailySummary(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error)
+	GetTrades(ctx context.Context, query models.TradeQuery) (models.TradePage, error)
 }
 
-func GetQuoteHandler(svc TradingService) gin.HandlerFunc {
+// GetQuoteHandler serves /quote. maxRangeDays caps the span between data_inicio and data_fim,
+// zero means no limit.
+func GetQuoteHandler(svc TradingService, maxRangeDays int) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ticker := c.Query("ticker")
 		if ticker == "" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
 			return
 		}
+		startDate, endDate, ok := daterange.Parse(c, maxRangeDays)
+		if !ok {
+			return
+		}
+
+		quote, err := svc.GetQuote(c.Request.Context(), ticker, startDate, endDate)
+		if errors.Is(err, models.ErrTickerNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "ticker_not_found", Ticker: ticker})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, newQuoteResponse(ticker, startDate, endDate, quote))
+	}
+}
+
+// GetQuotesHandler serves POST /v1/quotes, answering for every requested ticker with one query.
+// Results keep the order of the request, with duplicates and blank tickers removed.
+func GetQuotesHandler(svc TradingService, maxRangeDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BatchQuotesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		tickers := uniqueTickers(req.Tickers)
+		if len(tickers) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tickers is required"})
+			return
+		}
+		if len(tickers) > maxBatchTickers {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d tickers per request", maxBatchTickers)})
+			return
+		}
+		startDate, endDate, err := daterange.Resolve(req.DataInicio, req.DataFim, maxRangeDays)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-		dataInicio := c.Query("data_inicio")
-		var startDate time.Time
-		var err error
-		fmt.Printf("Received request for ticker: %s, startDate: %s\n", ticker, dataInicio)
+		quotes, err := svc.GetQuotes(c.Request.Context(), tickers, startDate, endDate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 
-		if dataInicio != "" {
-			startDate, err = time.Parse("2006-01-02", dataInicio)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "data_inicio inválida, use formato YYYY-MM-DD"})
-				return
+		resp := BatchQuotesResponse{
+			DataInicio: startDate.Format("2006-01-02"),
+			DataFim:    endDate.Format("2006-01-02"),
+			Quotes:     make([]BatchQuoteResult, 0, len(tickers)),
+		}
+		for _, ticker := range tickers {
+			result := BatchQuoteResult{Ticker: ticker}
+			if quote, ok := quotes[ticker]; ok {
+				q := newQuoteResponse(ticker, startDate, endDate, quote)
+				result.Found, result.Quote = true, &q
+			} else {
+				result.Error, result.Code = models.ErrTickerNotFound.Error(), "ticker_not_found"
 			}
-		} else {
-			startDate = time.Now().AddDate(0, 0, -7) // 7 dias atrás
+			resp.Quotes = append(resp.Quotes, result)
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func uniqueTickers(tickers []string) []string {
+	seen := make(map[string]bool, len(tickers))
+	unique := make([]string, 0, len(tickers))
+	for _, t := range tickers {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		unique = append(unique, t)
+	}
+	return unique
+}
+
+func newQuoteResponse(ticker string, startDate, endDate time.Time, q models.Quote) QuoteResponse {
+	resp := QuoteResponse{
+		Ticker:         ticker,
+		Empty:          q.Sessions == 0,
+		Sessions:       q.Sessions,
+		DataInicio:     startDate.Format("2006-01-02"),
+		DataFim:        endDate.Format("2006-01-02"),
+		MaxRangeValue:  q.MaxPrice,
+		MaxDailyVolume: q.MaxDailyVolume,
+		MinRangeValue:  q.MinPrice,
+		OpenPrice:      q.OpenPrice,
+		ClosePrice:     q.ClosePrice,
+		VWAP:           q.VWAP,
+		TotalVolume:    q.TotalVolume,
+		TradeCount:     q.TradeCount,
+	}
+	if q.MaxPriceDate != nil {
+		resp.MaxValueDate = q.MaxPriceDate.Format("2006-01-02")
+	}
+	if q.MinPriceDate != nil {
+		resp.MinValueDate = q.MinPriceDate.Format("2006-01-02")
+	}
+	return resp
+}
+
+func GetCandlesHandler(svc TradingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticker := c.Query("ticker")
+		if ticker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
+			return
 		}
 
-		maxPrice, maxVol, err := svc.GetQuote(c.Request.Context(), ticker, startDate)
+		date, err := time.Parse("2006-01-02", c.Query("date"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date is required, use format YYYY-MM-DD"})
+			return
+		}
+
+		intervalParam := c.DefaultQuery("interval", "5m")
+		interval, ok := candleIntervals[intervalParam]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be one of 1m, 5m, 15m, 1h"})
+			return
+		}
+
+		candles, err := svc.GetCandles(c.Request.Context(), ticker, date, interval)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		c.JSON(http.StatusOK, QuoteResponse{
-			Ticker:         ticker,
-			MaxRangeValue:  maxPrice,
-			MaxDailyVolume: maxVol,
-		})
+		resp := CandlesResponse{
+			Ticker:   ticker,
+			Date:     date.Format("2006-01-02"),
+			Interval: intervalParam,
+			Candles:  make([]CandleResponse, 0, len(candles)),
+		}
+		for _, candle := range candles {
+			resp.Candles = append(resp.Candles, CandleResponse{
+				Start:  candle.Start,
+				Open:   candle.Open,
+				High:   candle.High,
+				Low:    candle.Low,
+				Close:  candle.Close,
+				Volume: candle.Volume,
+				Trades: candle.Trades,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func GetDailySummaryHandler(svc TradingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticker := c.Query("ticker")
+		if ticker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
+			return
+		}
+		startDate, endDate, ok := daterange.Parse(c, 0)
+		if !ok {
+			return
+		}
+
+		days, err := svc.GetDailySummary(c.Request.Context(), ticker, startDate, endDate)
+		if errors.Is(err, models.ErrTickerNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error(), Code: "ticker_not_found", Ticker: ticker})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := DailySummariesResponse{
+			Ticker:     ticker,
+			DataInicio: startDate.Format("2006-01-02"),
+			DataFim:    endDate.Format("2006-01-02"),
+			Days:       make([]DailySummaryResponse, 0, len(days)),
+		}
+		for _, d := range days {
+			resp.Days = append(resp.Days, DailySummaryResponse{
+				Date:   d.Date.Format("2006-01-02"),
+				Open:   d.Open,
+				High:   d.High,
+				Low:    d.Low,
+				Close:  d.Close,
+				Volume: d.Volume,
+				Trades: d.Trades,
+				VWAP:   d.VWAP,
+				Return: d.Return,
+			})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// GetTradesHandler serves the raw trades of a ticker between from and to, oldest first. Pages hold
+// at most maxPageSize trades, and the next_cursor of a page is passed as cursor to get the next one.
+func GetTradesHandler(svc TradingService, maxPageSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, err := parseTradeQuery(c, maxPageSize)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		page, err := svc.GetTrades(c.Request.Context(), query)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := TradesResponse{
+			Ticker: query.Ticker,
+			From:   query.From.Format("2006-01-02"),
+			To:     query.To.Format("2006-01-02"),
+			Trades: make([]TradeResponse, 0, len(page.Trades)),
+		}
+		for _, t := range page.Trades {
+			resp.Trades = append(resp.Trades, TradeResponse{
+				Date:     t.Date.Format("2006-01-02"),
+				Time:     models.SessionTime(t.Date, t.ClosingTime),
+				Price:    t.Price,
+				Quantity: t.Quantity,
+				TradeID:  t.TradeID,
+			})
+		}
+		if page.Next != nil {
+			resp.NextCursor = encodeTradeCursor(*page.Next)
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func parseTradeQuery(c *gin.Context, maxPageSize int) (models.TradeQuery, error) {
+	query := models.TradeQuery{Ticker: c.Query("ticker"), Limit: defaultTradesPageSize}
+	if query.Ticker == "" {
+		return query, errors.New("ticker is required")
+	}
+	var err error
+	if query.From, err = time.Parse("2006-01-02", c.Query("from")); err != nil {
+		return query, errors.New("from is required, use format YYYY-MM-DD")
+	}
+	if query.To, err = time.Parse("2006-01-02", c.Query("to")); err != nil {
+		return query, errors.New("to is required, use format YYYY-MM-DD")
+	}
+	if query.From.After(query.To) {
+		return query, errors.New("from must not be after to")
+	}
+	if value := c.Query("min_qty"); value != "" {
+		if query.MinQuantity, err = strconv.ParseInt(value, 10, 64); err != nil || query.MinQuantity < 0 {
+			return query, errors.New("min_qty must be a non-negative integer")
+		}
+	}
+	if query.Limit > maxPageSize {
+		query.Limit = maxPageSize
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+		query.Limit = limit
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := decodeTradeCursor(value)
+		if err != nil {
+			return query, errors.New("invalid cursor")
+		}
+		query.After = &cursor
+	}
+	return query, nil
+}
+
+// encodeTradeCursor turns a position into the opaque next_cursor token. Clients must not rely on
+// its format.
+func encodeTradeCursor(cursor models.TradeCursor) string {
+	raw := fmt.Sprintf("%s|%d|%d", cursor.Date.Format("2006-01-02"), cursor.ClosingTime, cursor.TradeID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTradeCursor(token string) (models.TradeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return models.TradeCursor{}, err
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return models.TradeCursor{}, fmt.Errorf("malformed cursor %q", raw)
+	}
+	var cursor models.TradeCursor
+	if cursor.Date, err = time.Parse("2006-01-02", parts[0]); err != nil {
+		return models.TradeCursor{}, err
+	}
+	if cursor.ClosingTime, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return models.TradeCursor{}, err
+	}
+	if cursor.TradeID, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+		return models.TradeCursor{}, err
 	}
+	return cursor, nil
 }
@@ -1,10 +1,12 @@
 package trading
 
 import (
+	"b3-ingest/internal/domain/models"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,13 +14,77 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-type mockTradingService struct{}
+type mockTradingService struct {
+	gotTradeQuery models.TradeQuery
+}
 
-func (m *mockTradingService) GetQuote(ctx context.Context, ticker string, startDate time.Time) (float64, int64, error) {
+func (m *mockTradingService) GetQuote(ctx context.Context, ticker string, startDate, endDate time.Time) (models.Quote, error) {
+	switch ticker {
+	case "FAIL":
+		return models.Quote{}, assert.AnError
+	case "UNKNOWN":
+		return models.Quote{}, models.ErrTickerNotFound
+	case "EMPTY":
+		return models.Quote{Ticker: ticker}, nil
+	}
+	maxDate, minDate := startDate.AddDate(0, 0, 2), startDate.AddDate(0, 0, 1)
+	return models.Quote{
+		Ticker: ticker, Sessions: 21, MaxPrice: 123.45, MaxDailyVolume: 6789, MinPrice: 100, OpenPrice: 101, ClosePrice: 120,
+		VWAP: 110.5, TotalVolume: 20000, TradeCount: 42, MaxPriceDate: &maxDate, MinPriceDate: &minDate,
+	}, nil
+}
+
+func (m *mockTradingService) GetQuotes(ctx context.Context, tickers []string, startDate, endDate time.Time) (map[string]models.Quote, error) {
+	quotes := map[string]models.Quote{}
+	for _, ticker := range tickers {
+		switch ticker {
+		case "FAIL":
+			return nil, assert.AnError
+		case "UNKNOWN":
+		case "EMPTY":
+			quotes[ticker] = models.Quote{Ticker: ticker}
+		default:
+			quotes[ticker] = models.Quote{Ticker: ticker, MaxPrice: 10, TradeCount: 3}
+		}
+	}
+	return quotes, nil
+}
+
+func (m *mockTradingService) GetTrades(ctx context.Context, query models.TradeQuery) (models.TradePage, error) {
+	m.gotTradeQuery = query
+	if query.Ticker == "FAIL" {
+		return models.TradePage{}, assert.AnError
+	}
+	trade := models.Trade{Date: query.From, ClosingTime: 100501250, Price: 30.5, Quantity: 200, TradeID: 42}
+	next := trade.Cursor()
+	return models.TradePage{Trades: []models.Trade{trade}, Next: &next}, nil
+}
+
+func (m *mockTradingService) GetCandles(ctx context.Context, ticker string, date time.Time, interval time.Duration) ([]models.Candle, error) {
 	if ticker == "FAIL" {
-		return 0, 0, assert.AnError
+		return nil, assert.AnError
+	}
+	start := models.SessionTime(date, 100000000)
+	return []models.Candle{
+		{Start: start, Open: 10, High: 12, Low: 9, Close: 11, Volume: 300, Trades: 3},
+		{Start: start.Add(interval), Open: 11, High: 11, Low: 11, Close: 11, Volume: 100, Trades: 1},
+	}, nil
+}
+
+func (m *mockTradingService) GetDailySummary(ctx context.Context, ticker string, startDate, endDate time.Time) ([]models.DailySummary, error) {
+	switch ticker {
+	case "FAIL":
+		return nil, assert.AnError
+	case "UNKNOWN":
+		return nil, models.ErrTickerNotFound
+	case "EMPTY":
+		return nil, nil
 	}
-	return 123.45, 6789, nil
+	ret := 0.02
+	return []models.DailySummary{
+		{Date: startDate, Open: 100, High: 110, Low: 95, Close: 100, Volume: 1000, Trades: 10, VWAP: 101},
+		{Date: startDate.AddDate(0, 0, 1), Open: 100, High: 104, Low: 99, Close: 102, Volume: 500, Trades: 5, VWAP: 102, Return: &ret},
+	}, nil
 }
 
 func TestGetQuoteHandlerGivenValidTickerAndDateWhenRequestIsMadeThenReturnsSuccess(t *testing.T) {
@@ -26,8 +92,8 @@ func TestGetQuoteHandlerGivenValidTickerAndDateWhenRequestIsMadeThenReturnsSucce
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	r := gin.Default()
-	r.GET("/quote", GetQuoteHandler(&mockTradingService{}))
-	req, _ := http.NewRequest("GET", "/quote?ticker=TEST&data_inicio=2025-07-01", nil)
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
+	req, _ := http.NewRequest("GET", "/quote?ticker=TEST&data_inicio=2025-07-01&data_fim=2025-07-31", nil)
 
 	// Act
 	r.ServeHTTP(w, req)
@@ -40,6 +106,117 @@ func TestGetQuoteHandlerGivenValidTickerAndDateWhenRequestIsMadeThenReturnsSucce
 	assert.Equal(t, "TEST", resp.Ticker)
 	assert.Equal(t, 123.45, resp.MaxRangeValue)
 	assert.Equal(t, int64(6789), resp.MaxDailyVolume)
+	assert.Equal(t, 100.0, resp.MinRangeValue)
+	assert.Equal(t, 101.0, resp.OpenPrice)
+	assert.Equal(t, 120.0, resp.ClosePrice)
+	assert.Equal(t, 110.5, resp.VWAP)
+	assert.Equal(t, int64(20000), resp.TotalVolume)
+	assert.Equal(t, int64(42), resp.TradeCount)
+	assert.Equal(t, "2025-07-03", resp.MaxValueDate)
+	assert.Equal(t, "2025-07-02", resp.MinValueDate)
+	assert.Equal(t, "2025-07-01", resp.DataInicio)
+	assert.Equal(t, "2025-07-31", resp.DataFim)
+	assert.False(t, resp.Empty)
+	assert.Equal(t, int64(21), resp.Sessions)
+}
+
+func TestGetQuoteHandlerGivenUnknownTickerWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
+	req, _ := http.NewRequest("GET", "/quote?ticker=UNKNOWN", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var resp ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "ticker_not_found", resp.Code)
+	assert.Equal(t, "UNKNOWN", resp.Ticker)
+	assert.NotEmpty(t, resp.Error)
+}
+
+func TestGetQuoteHandlerGivenKnownTickerWithoutTradesWhenRequestIsMadeThenReturnsEmptyResult(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
+	req, _ := http.NewRequest("GET", "/quote?ticker=EMPTY", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, true, resp["empty"])
+	assert.Equal(t, 0.0, resp["sessions"])
+	assert.NotContains(t, resp, "max_value_date")
+}
+
+func TestGetQuoteHandlerGivenInvalidRangeWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 30))
+	queries := []string{
+		"ticker=TEST&data_inicio=2025-07-10&data_fim=2025-07-01",
+		"ticker=TEST&data_inicio=2025-07-01&data_fim=2025-07-31",
+		"ticker=TEST&data_fim=invalid-date",
+	}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/quote?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetQuoteHandlerGivenOnlyDataFimWhenRequestIsMadeThenStartsSevenDaysBefore(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 30))
+	req, _ := http.NewRequest("GET", "/quote?ticker=TEST&data_fim=2025-07-10", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp QuoteResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2025-07-03", resp.DataInicio)
+	assert.Equal(t, "2025-07-10", resp.DataFim)
+}
+
+func TestGetQuoteHandlerGivenRangeAtMaximumSpanWhenRequestIsMadeThenReturnsSuccess(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 30))
+	req, _ := http.NewRequest("GET", "/quote?ticker=TEST&data_inicio=2025-07-01&data_fim=2025-07-30", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
 }
 
 func TestGetQuoteHandlerGivenMissingTickerWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
@@ -47,7 +224,7 @@ func TestGetQuoteHandlerGivenMissingTickerWhenRequestIsMadeThenReturnsBadRequest
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	r := gin.Default()
-	r.GET("/quote", GetQuoteHandler(&mockTradingService{}))
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
 	req, _ := http.NewRequest("GET", "/quote", nil)
 
 	// Act
@@ -62,7 +239,7 @@ func TestGetQuoteHandlerGivenInvalidDateWhenRequestIsMadeThenReturnsBadRequest(t
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	r := gin.Default()
-	r.GET("/quote", GetQuoteHandler(&mockTradingService{}))
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
 	req, _ := http.NewRequest("GET", "/quote?ticker=TEST&data_inicio=invalid-date", nil)
 
 	// Act
@@ -77,7 +254,7 @@ func TestGetQuoteHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalSer
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
 	r := gin.Default()
-	r.GET("/quote", GetQuoteHandler(&mockTradingService{}))
+	r.GET("/quote", GetQuoteHandler(&mockTradingService{}, 366))
 	req, _ := http.NewRequest("GET", "/quote?ticker=FAIL", nil)
 
 	// Act
@@ -86,3 +263,324 @@ func TestGetQuoteHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalSer
 	// Assert
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestGetQuotesHandlerGivenTickersWhenRequestIsMadeThenMarksUnknownAndEmptyTickers(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.POST("/v1/quotes", GetQuotesHandler(&mockTradingService{}, 366))
+	body := `{"tickers": ["PETR4", "UNKNOWN", "PETR4", " ", "EMPTY"], "data_inicio": "2025-07-01", "data_fim": "2025-07-31"}`
+	req, _ := http.NewRequest("POST", "/v1/quotes", strings.NewReader(body))
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp BatchQuotesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-07-01", resp.DataInicio)
+	assert.Len(t, resp.Quotes, 3)
+	assert.Equal(t, "PETR4", resp.Quotes[0].Ticker)
+	assert.True(t, resp.Quotes[0].Found)
+	assert.Empty(t, resp.Quotes[0].Code)
+	assert.Equal(t, 10.0, resp.Quotes[0].Quote.MaxRangeValue)
+	assert.Equal(t, "UNKNOWN", resp.Quotes[1].Ticker)
+	assert.False(t, resp.Quotes[1].Found)
+	assert.Equal(t, "ticker_not_found", resp.Quotes[1].Code)
+	assert.Nil(t, resp.Quotes[1].Quote)
+	assert.Equal(t, "EMPTY", resp.Quotes[2].Ticker)
+	assert.True(t, resp.Quotes[2].Found)
+	if assert.NotNil(t, resp.Quotes[2].Quote) {
+		assert.True(t, resp.Quotes[2].Quote.Empty)
+	}
+}
+
+func TestGetQuotesHandlerGivenInvalidBodyWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/v1/quotes", GetQuotesHandler(&mockTradingService{}, 30))
+	bodies := []string{
+		`not json`,
+		`{"tickers": []}`,
+		`{"tickers": ["PETR4"], "data_inicio": "2025-07-10", "data_fim": "2025-07-01"}`,
+		`{"tickers": ["PETR4"], "data_inicio": "2025-01-01", "data_fim": "2025-07-01"}`,
+	}
+
+	for _, body := range bodies {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/quotes", strings.NewReader(body))
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestGetQuotesHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.POST("/v1/quotes", GetQuotesHandler(&mockTradingService{}, 366))
+	req, _ := http.NewRequest("POST", "/v1/quotes", strings.NewReader(`{"tickers": ["FAIL"]}`))
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetCandlesHandlerGivenValidParamsWhenRequestIsMadeThenReturnsCandles(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/candles", GetCandlesHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/candles?ticker=WDOQ25&date=2025-07-29&interval=15m", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp CandlesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "WDOQ25", resp.Ticker)
+	assert.Equal(t, "15m", resp.Interval)
+	assert.Len(t, resp.Candles, 2)
+	assert.Equal(t, 12.0, resp.Candles[0].High)
+	assert.Equal(t, 15*time.Minute, resp.Candles[1].Start.Sub(resp.Candles[0].Start))
+}
+
+func TestGetCandlesHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/candles", GetCandlesHandler(&mockTradingService{}))
+	urls := []string{
+		"/v1/candles?date=2025-07-29",
+		"/v1/candles?ticker=WDOQ25",
+		"/v1/candles?ticker=WDOQ25&date=2025-07-29&interval=2m",
+	}
+
+	for _, url := range urls {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+}
+
+func TestGetCandlesHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/candles", GetCandlesHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/candles?ticker=FAIL&date=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetDailySummaryHandlerGivenValidRangeWhenRequestIsMadeThenReturnsDays(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/daily", GetDailySummaryHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/daily?ticker=PETR4&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DailySummariesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-07-28", resp.DataInicio)
+	assert.Equal(t, "2025-07-29", resp.DataFim)
+	assert.Len(t, resp.Days, 2)
+	assert.Nil(t, resp.Days[0].Return)
+	assert.InDelta(t, 0.02, *resp.Days[1].Return, 1e-9)
+	assert.Equal(t, "2025-07-29", resp.Days[1].Date)
+}
+
+func TestGetDailySummaryHandlerGivenStartAfterEndWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/daily", GetDailySummaryHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/daily?ticker=PETR4&data_inicio=2025-07-29&data_fim=2025-07-28", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetDailySummaryHandlerGivenUnknownTickerWhenRequestIsMadeThenReturnsNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/daily", GetDailySummaryHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/daily?ticker=UNKNOWN", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var resp ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "ticker_not_found", resp.Code)
+	assert.Equal(t, "UNKNOWN", resp.Ticker)
+}
+
+func TestGetDailySummaryHandlerGivenKnownTickerWithoutSessionsWhenRequestIsMadeThenReturnsEmptyDays(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/daily", GetDailySummaryHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/daily?ticker=EMPTY", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DailySummariesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Days)
+}
+
+func TestGetDailySummaryHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/daily", GetDailySummaryHandler(&mockTradingService{}))
+	req, _ := http.NewRequest("GET", "/v1/daily?ticker=FAIL", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetTradesHandlerGivenValidParamsWhenRequestIsMadeThenReturnsPageWithCursor(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockTradingService{}
+	r := gin.Default()
+	r.GET("/v1/trades", GetTradesHandler(svc, 500))
+	req, _ := http.NewRequest("GET", "/v1/trades?ticker=PETR4&from=2025-07-29&to=2025-07-30&min_qty=100", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(100), svc.gotTradeQuery.MinQuantity)
+	assert.Equal(t, defaultTradesPageSize, svc.gotTradeQuery.Limit)
+	assert.Nil(t, svc.gotTradeQuery.After)
+	var resp TradesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Trades, 1)
+	assert.Equal(t, int64(42), resp.Trades[0].TradeID)
+	assert.Equal(t, "2025-07-29", resp.Trades[0].Date)
+	assert.Equal(t, 10, resp.Trades[0].Time.Hour())
+	assert.NotEmpty(t, resp.NextCursor)
+
+	cursor, err := decodeTradeCursor(resp.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TradeCursor{Date: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), ClosingTime: 100501250, TradeID: 42}, cursor)
+}
+
+func TestGetTradesHandlerGivenCursorWhenRequestIsMadeThenResumesAfterIt(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	svc := &mockTradingService{}
+	r := gin.Default()
+	r.GET("/v1/trades", GetTradesHandler(svc, 50))
+	cursor := encodeTradeCursor(models.TradeCursor{Date: time.Date(2025, 7, 29, 0, 0, 0, 0, time.UTC), ClosingTime: 90000000, TradeID: 7})
+	req, _ := http.NewRequest("GET", "/v1/trades?ticker=PETR4&from=2025-07-29&to=2025-07-30&cursor="+cursor, nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 50, svc.gotTradeQuery.Limit)
+	if assert.NotNil(t, svc.gotTradeQuery.After) {
+		assert.Equal(t, int64(7), svc.gotTradeQuery.After.TradeID)
+		assert.Equal(t, int64(90000000), svc.gotTradeQuery.After.ClosingTime)
+	}
+}
+
+func TestGetTradesHandlerGivenInvalidParamsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/trades", GetTradesHandler(&mockTradingService{}, 500))
+	queries := []string{
+		"from=2025-07-29&to=2025-07-30",
+		"ticker=PETR4&to=2025-07-30",
+		"ticker=PETR4&from=2025-07-31&to=2025-07-30",
+		"ticker=PETR4&from=2025-07-29&to=2025-07-30&min_qty=-1",
+		"ticker=PETR4&from=2025-07-29&to=2025-07-30&limit=501",
+		"ticker=PETR4&from=2025-07-29&to=2025-07-30&cursor=not-a-cursor",
+	}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/trades?"+query, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestGetTradesHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/trades", GetTradesHandler(&mockTradingService{}, 500))
+	req, _ := http.NewRequest("GET", "/v1/trades?ticker=FAIL&from=2025-07-29&to=2025-07-30", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
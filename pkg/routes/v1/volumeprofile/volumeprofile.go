@@ -0,0 +1,115 @@
+package volumeprofile
+
+import (
+	"b3-ingest/internal/domain/models"
+	"b3-ingest/pkg/routes/v1/daterange"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Ticker string `json:"ticker,omitempty"`
+}
+
+type PriceLevelResponse struct {
+	Price  float64 `json:"price"`
+	Volume int64   `json:"volume"`
+	Trades int64   `json:"trades"`
+}
+
+type ValueAreaResponse struct {
+	Low    float64 `json:"low"`
+	High   float64 `json:"high"`
+	Volume int64   `json:"volume"`
+}
+
+// VolumeProfileResponse leaves poc and value_area out when no trade falls in the range.
+type VolumeProfileResponse struct {
+	Ticker      string               `json:"ticker"`
+	DataInicio  string               `json:"data_inicio"`
+	DataFim     string               `json:"data_fim"`
+	TickSize    float64              `json:"tick_size"`
+	TotalVolume int64                `json:"total_volume"`
+	POC         *float64             `json:"poc,omitempty"`
+	ValueArea   *ValueAreaResponse   `json:"value_area,omitempty"`
+	Levels      []PriceLevelResponse `json:"levels"`
+}
+
+// defaultTickSize is the bin width of /v1/volume-profile when no tick_size is given, the price
+// increment of B3 equities.
+const defaultTickSize = 0.01
+
+// minTickSize is the smallest tick_size accepted. Smaller bins would overflow the bucket number
+// of a price level.
+const minTickSize = 1e-6
+
+type VolumeProfileService interface {
+	GetVolumeProfile(ctx context.Context, ticker string, startDate, endDate time.Time, tickSize float64) (models.VolumeProfile, error)
+}
+
+// GetVolumeProfileHandler serves /v1/volume-profile: the volume traded per price level, binned by
+// tick_size, with the point of control and the value area holding 70% of the volume. maxRangeDays
+// caps the span between data_inicio and data_fim, and a tick_size that splits the range into more
+// than models.MaxPriceLevels levels is rejected.
+func GetVolumeProfileHandler(svc VolumeProfileService, maxRangeDays int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ticker := c.Query("ticker")
+		if ticker == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ticker is required"})
+			return
+		}
+		startDate, endDate, ok := daterange.Parse(c, maxRangeDays)
+		if !ok {
+			return
+		}
+		tickSize := defaultTickSize
+		if raw := c.Query("tick_size"); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < minTickSize {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("tick_size must be a number of at least %g", minTickSize)})
+				return
+			}
+			tickSize = v
+		}
+
+		profile, err := svc.GetVolumeProfile(c.Request.Context(), ticker, startDate, endDate, tickSize)
+		if errors.Is(err, models.ErrTooManyPriceLevels) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error(), Code: "too_many_levels", Ticker: ticker})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := VolumeProfileResponse{
+			Ticker:      ticker,
+			DataInicio:  startDate.Format("2006-01-02"),
+			DataFim:     endDate.Format("2006-01-02"),
+			TickSize:    tickSize,
+			TotalVolume: profile.TotalVolume,
+			Levels:      make([]PriceLevelResponse, 0, len(profile.Levels)),
+		}
+		if len(profile.Levels) > 0 {
+			resp.POC = &profile.POC
+			resp.ValueArea = &ValueAreaResponse{
+				Low:    profile.ValueAreaLow,
+				High:   profile.ValueAreaHigh,
+				Volume: profile.ValueAreaVolume,
+			}
+		}
+		for _, l := range profile.Levels {
+			resp.Levels = append(resp.Levels, PriceLevelResponse{Price: l.Price, Volume: l.Volume, Trades: l.Trades})
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,146 @@
+package volumeprofile
+
+import (
+	"b3-ingest/internal/domain/models"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockVolumeProfileService struct {
+	gotTickSize float64
+}
+
+func (m *mockVolumeProfileService) GetVolumeProfile(ctx context.Context, ticker string, startDate, endDate time.Time, tickSize float64) (models.VolumeProfile, error) {
+	m.gotTickSize = tickSize
+	switch ticker {
+	case "FAIL":
+		return models.VolumeProfile{}, assert.AnError
+	case "EMPTY":
+		return models.NewVolumeProfile(nil), nil
+	case "DENSE":
+		return models.VolumeProfile{}, models.ErrTooManyPriceLevels
+	}
+	return models.NewVolumeProfile([]models.PriceLevel{
+		{Price: 32.0, Volume: 100, Trades: 1},
+		{Price: 32.1, Volume: 250, Trades: 2},
+		{Price: 32.3, Volume: 10, Trades: 1},
+	}), nil
+}
+
+func TestGetVolumeProfileHandlerGivenTickerWhenRequestIsMadeThenReturnsLevelsAndPOC(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockVolumeProfileService{}
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(svc, 366))
+	req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=PETR4&data_inicio=2025-07-28&data_fim=2025-07-29&tick_size=0.1", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 0.1, svc.gotTickSize)
+	var resp VolumeProfileResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Levels, 3)
+	assert.Equal(t, int64(360), resp.TotalVolume)
+	assert.Equal(t, 32.1, *resp.POC)
+	assert.Equal(t, 32.0, resp.ValueArea.Low)
+	assert.Equal(t, 32.1, resp.ValueArea.High)
+}
+
+func TestGetVolumeProfileHandlerGivenNoTradesWhenRequestIsMadeThenOmitsPOC(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	svc := &mockVolumeProfileService{}
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(svc, 366))
+	req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=EMPTY&data_inicio=2025-07-28&data_fim=2025-07-29", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultTickSize, svc.gotTickSize)
+	assert.NotContains(t, w.Body.String(), "poc")
+	assert.Contains(t, w.Body.String(), `"levels":[]`)
+}
+
+func TestGetVolumeProfileHandlerGivenInvalidTickSizeWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(&mockVolumeProfileService{}, 366))
+
+	for _, tick := range []string{"0", "-0.5", "abc", "NaN", "Inf", "1e-300"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=PETR4&tick_size="+tick, nil)
+
+		// Act
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, tick)
+	}
+}
+
+func TestGetVolumeProfileHandlerGivenServiceErrorWhenRequestIsMadeThenReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(&mockVolumeProfileService{}, 366))
+	req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=FAIL", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetVolumeProfileHandlerGivenRangeOverMaximumWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(&mockVolumeProfileService{}, 30))
+	req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=PETR4&data_inicio=2025-01-01&data_fim=2025-07-31", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetVolumeProfileHandlerGivenTooManyLevelsWhenRequestIsMadeThenReturnsBadRequest(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.Default()
+	r.GET("/v1/volume-profile", GetVolumeProfileHandler(&mockVolumeProfileService{}, 366))
+	req, _ := http.NewRequest("GET", "/v1/volume-profile?ticker=DENSE&tick_size=0.000001", nil)
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "too_many_levels", resp.Code)
+}